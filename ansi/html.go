@@ -0,0 +1,203 @@
+package ansi
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// HTMLOptions controls how ANSI styled text is converted to HTML.
+type HTMLOptions struct {
+	Classes     bool   // use CSS classes (see HTMLStylesheet) instead of inline styles
+	ClassPrefix string // prefix for all CSS class names, e.g. "glog-"
+	Foreground  string // CSS color of default text, used for reversed text
+	Background  string // CSS color of the default background, used for reversed text
+}
+
+// NewHTMLOptions returns options for inline styles on a dark background.
+func NewHTMLOptions() *HTMLOptions {
+	return &HTMLOptions{
+		Classes:     false,
+		ClassPrefix: "glog-",
+		Foreground:  "#cccccc",
+		Background:  "#000000",
+	}
+}
+
+func (o *HTMLOptions) inlineStyle(s Style) string {
+	fg, bg := "", ""
+	if s.Fg >= 0 {
+		fg = Hex(s.Fg)
+	}
+	if s.Bg >= 0 {
+		bg = Hex(s.Bg)
+	}
+	if s.Reverse {
+		fg, bg = bg, fg
+		if fg == "" {
+			fg = o.Background
+		}
+		if bg == "" {
+			bg = o.Foreground
+		}
+	}
+
+	css := []string{}
+	if fg != "" {
+		css = append(css, "color:"+fg)
+	}
+	if bg != "" {
+		css = append(css, "background-color:"+bg)
+	}
+	if s.Bold {
+		css = append(css, "font-weight:bold")
+	}
+	if s.Dim {
+		css = append(css, "opacity:0.6")
+	}
+	if s.Italic {
+		css = append(css, "font-style:italic")
+	}
+	if deco := textDecoration(s); deco != "" {
+		css = append(css, "text-decoration:"+deco)
+	}
+	if s.Blink {
+		css = append(css, "animation:"+o.ClassPrefix+"blink 1s step-end infinite")
+	}
+	if s.Conceal {
+		css = append(css, "visibility:hidden")
+	}
+	return strings.Join(css, ";")
+}
+
+func textDecoration(s Style) string {
+	deco := []string{}
+	if s.Underline || s.DoubleUnderline {
+		deco = append(deco, "underline")
+	}
+	if s.StrikeThrough {
+		deco = append(deco, "line-through")
+	}
+	if s.DoubleUnderline {
+		deco = append(deco, "double")
+	}
+	return strings.Join(deco, " ")
+}
+
+func (o *HTMLOptions) classes(s Style) string {
+	fg, bg := "", ""
+	if s.Fg >= 0 {
+		fg = fmt.Sprintf("fg-%d", s.Fg)
+	}
+	if s.Bg >= 0 {
+		bg = fmt.Sprintf("bg-%d", s.Bg)
+	}
+	if s.Reverse {
+		fg, bg = strings.Replace(bg, "bg-", "fg-", 1), strings.Replace(fg, "fg-", "bg-", 1)
+		if fg == "" {
+			fg = "fg-reverse"
+		}
+		if bg == "" {
+			bg = "bg-reverse"
+		}
+	}
+
+	names := []string{}
+	for _, c := range []struct {
+		on   bool
+		name string
+	}{
+		{fg != "", fg}, {bg != "", bg}, {s.Bold, "bold"}, {s.Dim, "dim"}, {s.Italic, "italic"},
+		{s.Underline && !s.DoubleUnderline, "underline"}, {s.DoubleUnderline, "double-underline"},
+		{s.StrikeThrough, "strike"}, {s.Blink, "blink"}, {s.Conceal, "conceal"},
+	} {
+		if c.on {
+			names = append(names, o.ClassPrefix+c.name)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// HTMLStylesheet returns the CSS rules for the classes used by ToHTML if `opts.Classes` is enabled.
+func HTMLStylesheet(opts *HTMLOptions) string {
+	if opts == nil {
+		opts = NewHTMLOptions()
+	}
+	p := opts.ClassPrefix
+	var sb strings.Builder
+	for i := 0; i < 256; i++ {
+		fmt.Fprintf(&sb, ".%sfg-%d{color:%s}\n", p, i, Hex(i))
+	}
+	for i := 0; i < 256; i++ {
+		fmt.Fprintf(&sb, ".%sbg-%d{background-color:%s}\n", p, i, Hex(i))
+	}
+	fmt.Fprintf(&sb, ".%sfg-reverse{color:%s}\n", p, opts.Background)
+	fmt.Fprintf(&sb, ".%sbg-reverse{background-color:%s}\n", p, opts.Foreground)
+	fmt.Fprintf(&sb, ".%sbold{font-weight:bold}\n", p)
+	fmt.Fprintf(&sb, ".%sdim{opacity:0.6}\n", p)
+	fmt.Fprintf(&sb, ".%sitalic{font-style:italic}\n", p)
+	fmt.Fprintf(&sb, ".%sunderline{text-decoration:underline}\n", p)
+	fmt.Fprintf(&sb, ".%sdouble-underline{text-decoration:underline double}\n", p)
+	fmt.Fprintf(&sb, ".%sstrike{text-decoration:line-through}\n", p)
+	fmt.Fprintf(&sb, ".%sunderline.%sstrike{text-decoration:underline line-through}\n", p, p)
+	fmt.Fprintf(&sb, ".%sconceal{visibility:hidden}\n", p)
+	fmt.Fprintf(&sb, ".%sblink{animation:%sblink 1s step-end infinite}\n", p, p)
+	fmt.Fprintf(&sb, "@keyframes %sblink{50%%{opacity:0}}\n", p)
+	return sb.String()
+}
+
+// ToHTML converts the SGR sequences in `str` to HTML spans and escapes everything else.
+// Other escape sequences (cursor movements, screen control, ...) are dropped.
+// If `opts` is nil, the defaults of NewHTMLOptions are used.
+func ToHTML(str string, opts *HTMLOptions) string {
+	if opts == nil {
+		opts = NewHTMLOptions()
+	}
+	var sb strings.Builder
+	for _, sp := range Parse(str) {
+		text := html.EscapeString(sp.Text)
+		switch {
+		case sp.Style.IsPlain():
+			sb.WriteString(text)
+		case opts.Classes:
+			fmt.Fprintf(&sb, `<span class="%s">%s</span>`, opts.classes(sp.Style), text)
+		default:
+			fmt.Fprintf(&sb, `<span style="%s">%s</span>`, opts.inlineStyle(sp.Style), text)
+		}
+	}
+	return sb.String()
+}
+
+// WriteHTML reads ANSI styled text (e.g. a color log file written by `Logger.EnableColorLog`)
+// line by line from `r` and writes it as a standalone HTML document to `w`.
+// If `opts` is nil, the defaults of NewHTMLOptions are used.
+func WriteHTML(w io.Writer, r io.Reader, title string, opts *HTMLOptions) error {
+	if opts == nil {
+		opts = NewHTMLOptions()
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n", html.EscapeString(title))
+	fmt.Fprintf(bw, "body{margin:0;background:%s;color:%s}\npre{margin:0;padding:0.5em;font-family:monospace}\n", opts.Background, opts.Foreground)
+	if opts.Classes {
+		bw.WriteString(HTMLStylesheet(opts))
+	} else {
+		fmt.Fprintf(bw, "@keyframes %sblink{50%%{opacity:0}}\n", opts.ClassPrefix)
+	}
+	bw.WriteString("</style>\n</head>\n<body>\n<pre>")
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		// every glog line ends with a reset, so lines can be converted independently
+		bw.WriteString(ToHTML(sc.Text(), opts))
+		bw.WriteString("\n")
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	bw.WriteString("</pre>\n</body>\n</html>\n")
+	return bw.Flush()
+}
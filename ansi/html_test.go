@@ -0,0 +1,130 @@
+package ansi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	classes := NewHTMLOptions()
+	classes.Classes = true
+
+	tests := []struct {
+		input    string
+		opts     *HTMLOptions
+		expected string
+	}{
+		{
+			input:    "plain <b> & \"text\"",
+			expected: "plain &lt;b&gt; &amp; &#34;text&#34;",
+		},
+		{
+			input:    "\033[38;5;196mred\033[0m normal",
+			expected: `<span style="color:#ff0000">red</span> normal`,
+		},
+		{
+			input:    "\033[1;48;5;21m<x>\033[0m",
+			expected: `<span style="background-color:#0000ff;font-weight:bold">&lt;x&gt;</span>`,
+		},
+		{
+			input:    "\033[38;2;0;255;0mrgb\033[m",
+			expected: `<span style="color:#00ff00">rgb</span>`,
+		},
+		{
+			input:    "\033[7mreversed\033[0m",
+			expected: `<span style="color:#000000;background-color:#cccccc">reversed</span>`,
+		},
+		{
+			input:    "\033[2;3;4;9;8mall\033[0m",
+			expected: `<span style="opacity:0.6;font-style:italic;text-decoration:underline line-through;visibility:hidden">all</span>`,
+		},
+		{
+			input:    "\033[5mblink\033[0m",
+			expected: `<span style="animation:glog-blink 1s step-end infinite">blink</span>`,
+		},
+		{
+			input:    "\033[2J\033[1;1Hcursor\033[K moves",
+			expected: "cursor moves",
+		},
+		{
+			input:    "\033[1;38;5;45mclass\033[0m",
+			opts:     classes,
+			expected: `<span class="glog-fg-45 glog-bold">class</span>`,
+		},
+		{
+			input:    "\033[7;38;5;45mreversed\033[0m",
+			opts:     classes,
+			expected: `<span class="glog-fg-reverse glog-bg-45">reversed</span>`,
+		},
+		{
+			input:    "\033[21;9mdecorated\033[0m",
+			opts:     classes,
+			expected: `<span class="glog-double-underline glog-strike">decorated</span>`,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := ToHTML(tt.input, tt.opts); got != tt.expected {
+			t.Errorf("ToHTML(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestHTMLStylesheet(t *testing.T) {
+	opts := NewHTMLOptions()
+	opts.ClassPrefix = "x-"
+	css := HTMLStylesheet(opts)
+	for _, rule := range []string{
+		".x-fg-0{color:#000000}\n",
+		".x-fg-255{color:#eeeeee}\n",
+		".x-bg-196{background-color:#ff0000}\n",
+		".x-fg-reverse{color:#000000}\n",
+		".x-bg-reverse{background-color:#cccccc}\n",
+		".x-bold{font-weight:bold}\n",
+		"@keyframes x-blink{50%{opacity:0}}\n",
+	} {
+		if !strings.Contains(css, rule) {
+			t.Errorf("HTMLStylesheet() doesn't contain %q", rule)
+		}
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	classes := NewHTMLOptions()
+	classes.Classes = true
+
+	tests := []struct {
+		opts     *HTMLOptions
+		contains []string
+	}{
+		{
+			opts: nil,
+			contains: []string{
+				"<title>a &amp; b</title>",
+				"body{margin:0;background:#000000;color:#cccccc}",
+				"@keyframes glog-blink",
+				"<pre><span style=\"color:#ff0000\">red</span>\nsecond &lt;line&gt;\n</pre>",
+			},
+		},
+		{
+			opts: classes,
+			contains: []string{
+				".glog-fg-196{color:#ff0000}",
+				"<pre><span class=\"glog-fg-196\">red</span>\nsecond &lt;line&gt;\n</pre>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := WriteHTML(&buf, strings.NewReader("\033[38;5;196mred\033[0m\nsecond <line>\n"), "a & b", tt.opts); err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range tt.contains {
+			if !strings.Contains(buf.String(), s) {
+				t.Errorf("WriteHTML() = %q, want it to contain %q", buf.String(), s)
+			}
+		}
+	}
+}
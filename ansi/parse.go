@@ -0,0 +1,163 @@
+package ansi
+
+import "strings"
+
+// Token is either a run of plain text or a single escape sequence.
+type Token struct {
+	Text     string // plain text, empty for escape sequences
+	Sequence string // complete escape sequence (including ESC), empty for plain text
+}
+
+// IsEscape returns true if the token is an escape sequence.
+func (t Token) IsEscape() bool {
+	return t.Sequence != ""
+}
+
+// SGR returns the parameters of an SGR sequence (`\033[...m`) and whether the token is one.
+func (t Token) SGR() (params string, ok bool) {
+	s := t.Sequence
+	if len(s) < 3 || s[1] != '[' || s[len(s)-1] != 'm' {
+		return "", false
+	}
+	params = s[2 : len(s)-1]
+	for i := 0; i < len(params); i++ {
+		if c := params[i]; (c < '0' || c > '9') && c != ';' {
+			return "", false // private modes and intermediates are no SGR parameters
+		}
+	}
+	return params, true
+}
+
+// Span is a run of text rendered with the same style.
+type Span struct {
+	Text  string
+	Style Style
+}
+
+// String returns the text of the span wrapped in its style sequence and a reset.
+func (sp Span) String() string {
+	seq := sp.Style.Sequence()
+	if seq == "" {
+		return sp.Text
+	}
+	return seq + sp.Text + Reset().sequence
+}
+
+// escapeLength returns the length of the escape sequence starting at `str[0]` (which must be ESC).
+// Unterminated sequences extend to the end of the string.
+func escapeLength(str string) int {
+	if len(str) < 2 {
+		return len(str)
+	}
+	switch c := str[1]; {
+	case c == '[': // CSI: parameters, intermediates, final byte
+		i := 2
+		for i < len(str) && str[i] >= 0x20 && str[i] <= 0x3F {
+			i++
+		}
+		if i < len(str) && str[i] >= 0x40 && str[i] <= 0x7E {
+			return i + 1
+		}
+		return i
+	case c == ']' || c == 'P' || c == 'X' || c == '^' || c == '_': // OSC, DCS, SOS, PM, APC: terminated by BEL or ST
+		for i := 2; i < len(str); i++ {
+			if str[i] == '\a' && c == ']' {
+				return i + 1
+			}
+			if str[i] == '\033' && i+1 < len(str) && str[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(str)
+	case c >= 0x20 && c <= 0x2F: // nF: intermediates followed by a final byte, e.g. `\033(B`
+		i := 1
+		for i < len(str) && str[i] >= 0x20 && str[i] <= 0x2F {
+			i++
+		}
+		if i < len(str) && str[i] >= 0x30 && str[i] <= 0x7E {
+			return i + 1
+		}
+		return i
+	case c >= 0x30 && c <= 0x7E: // two-byte sequences, e.g. `\0337` (save cursor)
+		return 2
+	}
+	return 1 // lone ESC
+}
+
+// Tokenize splits `str` into plain text and escape sequences (CSI, OSC, DCS, charset selection etc.).
+func Tokenize(str string) []Token {
+	tokens := []Token{}
+	start := 0
+	for i := 0; i < len(str); {
+		if str[i] != '\033' {
+			i++
+			continue
+		}
+		if start < i {
+			tokens = append(tokens, Token{Text: str[start:i]})
+		}
+		n := escapeLength(str[i:])
+		tokens = append(tokens, Token{Sequence: str[i : i+n]})
+		i += n
+		start = i
+	}
+	if start < len(str) {
+		tokens = append(tokens, Token{Text: str[start:]})
+	}
+	return tokens
+}
+
+// Parse turns `str` into spans of text with their resolved style.
+// Adjacent text with the same style is merged into one span,
+// escape sequences other than SGR are dropped.
+func Parse(str string) []Span {
+	spans := []Span{}
+	style := NewStyle()
+	for _, t := range Tokenize(str) {
+		if params, ok := t.SGR(); ok {
+			style.ApplySGR(params)
+			continue
+		}
+		if t.IsEscape() || t.Text == "" {
+			continue
+		}
+		if n := len(spans); n > 0 && spans[n-1].Style == style {
+			spans[n-1].Text += t.Text
+			continue
+		}
+		spans = append(spans, Span{Text: t.Text, Style: style})
+	}
+	return spans
+}
+
+// Render turns spans back into a string, emitting only the sequences needed to switch between styles.
+func Render(spans []Span) string {
+	var sb strings.Builder
+	current := NewStyle()
+	for _, sp := range spans {
+		if sp.Style != current {
+			if !current.IsPlain() {
+				sb.WriteString(Reset().sequence)
+			}
+			sb.WriteString(sp.Style.Sequence())
+			current = sp.Style
+		}
+		sb.WriteString(sp.Text)
+	}
+	if !current.IsPlain() {
+		sb.WriteString(Reset().sequence)
+	}
+	return sb.String()
+}
+
+// Strip removes all escape sequences from `str`.
+func Strip(str string) string {
+	if !strings.ContainsRune(str, '\033') {
+		return str
+	}
+	var sb strings.Builder
+	for _, t := range Tokenize(str) {
+		sb.WriteString(t.Text)
+	}
+	return sb.String()
+}
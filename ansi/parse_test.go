@@ -0,0 +1,116 @@
+package ansi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "no escapes",
+			expected: "no escapes",
+		},
+		{
+			input:    "\033[38;5;45mcolor\033[0m",
+			expected: "color",
+		},
+		{
+			input:    "\033[38;5;45;1;48;5;16mcombined\033[m",
+			expected: "combined",
+		},
+		{
+			input:    "\033[?25lhidden cursor\033[?25h",
+			expected: "hidden cursor",
+		},
+		{
+			input:    "\033]0;window title\atext",
+			expected: "text",
+		},
+		{
+			input:    "\033]8;;https://example.com\033\\link\033]8;;\033\\",
+			expected: "link",
+		},
+		{
+			input:    "\033(Bcharset\0337saved\0338",
+			expected: "charsetsaved",
+		},
+		{
+			input:    "\033[2J\033[1;1Hcleared\033[K",
+			expected: "cleared",
+		},
+		{
+			input:    "unterminated \033[38;5",
+			expected: "unterminated ",
+		},
+	}
+
+	for _, tt := range tests {
+		got := Strip(tt.input)
+		if got != tt.expected {
+			t.Errorf("Strip(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	bold := NewStyle()
+	bold.Bold = true
+	boldBlue := bold
+	boldBlue.Fg = 45
+	blue := NewStyle()
+	blue.Fg = 45
+	blueOnBlack := blue
+	blueOnBlack.Bg = 0
+
+	tests := []struct {
+		input    string
+		expected []Span
+	}{
+		{
+			input:    "plain",
+			expected: []Span{{Text: "plain", Style: NewStyle()}},
+		},
+		{
+			input: "\033[1mbold \033[38;5;45mblue\033[0m plain",
+			expected: []Span{
+				{Text: "bold ", Style: bold},
+				{Text: "blue", Style: boldBlue},
+				{Text: " plain", Style: NewStyle()},
+			},
+		},
+		{
+			input: "\033[38;5;45ma\033[Kb\033[40mc\033[22m",
+			expected: []Span{
+				{Text: "ab", Style: blue},
+				{Text: "c", Style: blueOnBlack},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got := Parse(tt.input)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []string{
+		"plain",
+		"\033[1mbold \033[38;5;45mblue\033[0m plain",
+		"\033[4;48;5;16munderlined\033[24m on black\033[0m",
+	}
+
+	for _, input := range tests {
+		spans := Parse(input)
+		got := Parse(Render(spans))
+		if !reflect.DeepEqual(got, spans) {
+			t.Errorf("Parse(Render(Parse(%q))) = %+v, want %+v", input, got, spans)
+		}
+	}
+}
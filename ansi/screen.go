@@ -43,3 +43,11 @@ func EnableLineWrap() *ANSI {
 func DisableLineWrap() *ANSI {
 	return New("\033[?7l")
 }
+
+func EnterAlternateScreen() *ANSI {
+	return New("\033[?1049h")
+}
+
+func ExitAlternateScreen() *ANSI {
+	return New("\033[?1049l")
+}
@@ -0,0 +1,202 @@
+package ansi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Style is the text style resulting from a series of SGR (Select Graphic Rendition) sequences.
+// Colors are ANSI 256-color indices, -1 means the terminal's default color.
+type Style struct {
+	Fg, Bg          int
+	Bold            bool
+	Dim             bool
+	Italic          bool
+	Underline       bool
+	DoubleUnderline bool
+	Blink           bool
+	Reverse         bool
+	Conceal         bool
+	StrikeThrough   bool
+}
+
+// NewStyle returns a style without colors and attributes.
+func NewStyle() Style {
+	return Style{Fg: -1, Bg: -1}
+}
+
+// IsPlain returns true if the style has neither colors nor attributes.
+func (s Style) IsPlain() bool {
+	return s == NewStyle()
+}
+
+// Sequence returns the SGR sequence that establishes the style from a reset state.
+// Plain styles return an empty string.
+func (s Style) Sequence() string {
+	params := []string{}
+	for _, a := range []struct {
+		on   bool
+		code string
+	}{
+		{s.Bold, "1"}, {s.Dim, "2"}, {s.Italic, "3"}, {s.Underline, "4"}, {s.Blink, "5"},
+		{s.Reverse, "7"}, {s.Conceal, "8"}, {s.StrikeThrough, "9"}, {s.DoubleUnderline, "21"},
+	} {
+		if a.on {
+			params = append(params, a.code)
+		}
+	}
+	if s.Fg >= 0 {
+		params = append(params, fmt.Sprintf("38;5;%d", s.Fg))
+	}
+	if s.Bg >= 0 {
+		params = append(params, fmt.Sprintf("48;5;%d", s.Bg))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\033[" + strings.Join(params, ";") + "m"
+}
+
+// ApplySGR updates the style with the parameters of an SGR sequence,
+// e.g. "1;38;5;45" for `\033[1;38;5;45m`.
+func (s *Style) ApplySGR(params string) {
+	if params == "" {
+		*s = NewStyle()
+		return
+	}
+	p := strings.Split(params, ";")
+	num := func(i int) int {
+		if i >= len(p) {
+			return -1
+		}
+		n, err := strconv.Atoi(p[i])
+		if err != nil {
+			return 0 // empty parameters default to 0
+		}
+		return n
+	}
+	for i := 0; i < len(p); i++ {
+		switch n := num(i); {
+		case n == 0:
+			*s = NewStyle()
+		case n == 1:
+			s.Bold = true
+		case n == 2:
+			s.Dim = true
+		case n == 3:
+			s.Italic = true
+		case n == 4:
+			s.Underline = true
+		case n == 5 || n == 6:
+			s.Blink = true
+		case n == 7:
+			s.Reverse = true
+		case n == 8:
+			s.Conceal = true
+		case n == 9:
+			s.StrikeThrough = true
+		case n == 21:
+			s.DoubleUnderline = true
+		case n == 22:
+			s.Bold, s.Dim = false, false
+		case n == 23:
+			s.Italic = false
+		case n == 24:
+			s.Underline, s.DoubleUnderline = false, false
+		case n == 25:
+			s.Blink = false
+		case n == 27:
+			s.Reverse = false
+		case n == 28:
+			s.Conceal = false
+		case n == 29:
+			s.StrikeThrough = false
+		case n >= 30 && n <= 37:
+			s.Fg = n - 30
+		case n >= 40 && n <= 47:
+			s.Bg = n - 40
+		case n >= 90 && n <= 97:
+			s.Fg = n - 90 + 8
+		case n >= 100 && n <= 107:
+			s.Bg = n - 100 + 8
+		case n == 39:
+			s.Fg = -1
+		case n == 49:
+			s.Bg = -1
+		case n == 38 || n == 48:
+			c := -1
+			switch num(i + 1) {
+			case 5: // 256 colors
+				c = num(i + 2)
+				i += 2
+			case 2: // true color, approximated with the 256-color palette
+				c = rgbTo256(num(i+2), num(i+3), num(i+4))
+				i += 4
+			default:
+				i = len(p) // malformed, ignore the rest
+				continue
+			}
+			if c < 0 || c > 255 {
+				c = -1
+			}
+			if n == 38 {
+				s.Fg = c
+			} else {
+				s.Bg = c
+			}
+		}
+	}
+}
+
+var paletteBase = [16][3]int{
+	{0x00, 0x00, 0x00}, {0x80, 0x00, 0x00}, {0x00, 0x80, 0x00}, {0x80, 0x80, 0x00},
+	{0x00, 0x00, 0x80}, {0x80, 0x00, 0x80}, {0x00, 0x80, 0x80}, {0xc0, 0xc0, 0xc0},
+	{0x80, 0x80, 0x80}, {0xff, 0x00, 0x00}, {0x00, 0xff, 0x00}, {0xff, 0xff, 0x00},
+	{0x00, 0x00, 0xff}, {0xff, 0x00, 0xff}, {0x00, 0xff, 0xff}, {0xff, 0xff, 0xff},
+}
+
+func cubeLevel(v int) int {
+	if v == 0 {
+		return 0
+	}
+	return 55 + v*40
+}
+
+// RGB returns the red, green and blue components of the given ANSI 256-color index (xterm palette).
+func RGB(color int) (r, g, b int) {
+	switch {
+	case color < 0 || color > 255:
+		return 0, 0, 0
+	case color < 16:
+		c := paletteBase[color]
+		return c[0], c[1], c[2]
+	case color < 232:
+		color -= 16
+		return cubeLevel(color / 36), cubeLevel((color / 6) % 6), cubeLevel(color % 6)
+	}
+	g = 8 + (color-232)*10
+	return g, g, g
+}
+
+// Hex returns the given ANSI 256-color index as CSS hex color (xterm palette).
+func Hex(color int) string {
+	r, g, b := RGB(color)
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+func rgbTo256(r, g, b int) int {
+	if r < 0 || g < 0 || b < 0 {
+		return -1
+	}
+	toCube := func(v int) int {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return min(5, (v-35)/40)
+	}
+	return 16 + 36*toCube(r) + 6*toCube(g) + toCube(b)
+}
@@ -0,0 +1,97 @@
+package ansi
+
+import (
+	"testing"
+)
+
+func TestApplySGR(t *testing.T) {
+	style := func(modify func(s *Style)) Style {
+		s := NewStyle()
+		modify(&s)
+		return s
+	}
+
+	tests := []struct {
+		start    Style
+		params   string
+		expected Style
+	}{
+		{start: NewStyle(), params: "1", expected: style(func(s *Style) { s.Bold = true })},
+		{start: NewStyle(), params: "1;3;4;9", expected: style(func(s *Style) { s.Bold, s.Italic, s.Underline, s.StrikeThrough = true, true, true, true })},
+		{start: NewStyle(), params: "2;5;7;8;21", expected: style(func(s *Style) { s.Dim, s.Blink, s.Reverse, s.Conceal, s.DoubleUnderline = true, true, true, true, true })},
+		{start: NewStyle(), params: "38;5;45", expected: style(func(s *Style) { s.Fg = 45 })},
+		{start: NewStyle(), params: "48;5;200;1", expected: style(func(s *Style) { s.Bg, s.Bold = 200, true })},
+		{start: NewStyle(), params: "31;42", expected: style(func(s *Style) { s.Fg, s.Bg = 1, 2 })},
+		{start: NewStyle(), params: "91;102", expected: style(func(s *Style) { s.Fg, s.Bg = 9, 10 })},
+		{start: NewStyle(), params: "38;2;255;0;0", expected: style(func(s *Style) { s.Fg = 196 })},
+		{start: NewStyle(), params: "48;2;0;0;0", expected: style(func(s *Style) { s.Bg = 16 })},
+		{start: NewStyle(), params: "38;5;300", expected: NewStyle()}, // out of range
+		{start: NewStyle(), params: "38;7;1;1", expected: NewStyle()}, // malformed, the rest is ignored
+		{start: style(func(s *Style) { s.Bold = true }), params: "", expected: NewStyle()},
+		{start: style(func(s *Style) { s.Bold, s.Fg = true, 4 }), params: "0", expected: NewStyle()},
+		{start: style(func(s *Style) { s.Bold, s.Dim = true, true }), params: "22", expected: NewStyle()},
+		{start: style(func(s *Style) { s.Underline, s.DoubleUnderline = true, true }), params: "24", expected: NewStyle()},
+		{start: style(func(s *Style) { s.Fg, s.Bg = 4, 5 }), params: "39;49", expected: NewStyle()},
+		{start: style(func(s *Style) {
+			s.Italic, s.Blink, s.Reverse, s.Conceal, s.StrikeThrough = true, true, true, true, true
+		}), params: "23;25;27;28;29", expected: NewStyle()},
+	}
+
+	for _, tt := range tests {
+		got := tt.start
+		got.ApplySGR(tt.params)
+		if got != tt.expected {
+			t.Errorf("ApplySGR(%q) = %+v, want %+v", tt.params, got, tt.expected)
+		}
+	}
+}
+
+func TestStyleSequence(t *testing.T) {
+	tests := []struct {
+		style    Style
+		expected string
+	}{
+		{style: NewStyle(), expected: ""},
+		{style: Style{Fg: 45, Bg: -1, Bold: true}, expected: "\033[1;38;5;45m"},
+		{style: Style{Fg: -1, Bg: 16, Underline: true, StrikeThrough: true}, expected: "\033[4;9;48;5;16m"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.style.Sequence(); got != tt.expected {
+			t.Errorf("%+v.Sequence() = %q, want %q", tt.style, got, tt.expected)
+		}
+		// the sequence establishes the style again
+		s := NewStyle()
+		if tt.expected != "" {
+			s.ApplySGR(tt.expected[2 : len(tt.expected)-1])
+		}
+		if s != tt.style {
+			t.Errorf("ApplySGR(%+v.Sequence()) = %+v", tt.style, s)
+		}
+	}
+}
+
+func TestHex(t *testing.T) {
+	tests := []struct {
+		color    int
+		expected string
+	}{
+		{color: 0, expected: "#000000"},
+		{color: 1, expected: "#800000"},
+		{color: 15, expected: "#ffffff"},
+		{color: 16, expected: "#000000"},
+		{color: 21, expected: "#0000ff"},
+		{color: 196, expected: "#ff0000"},
+		{color: 110, expected: "#87afd7"},
+		{color: 232, expected: "#080808"},
+		{color: 255, expected: "#eeeeee"},
+		{color: -1, expected: "#000000"},
+		{color: 256, expected: "#000000"},
+	}
+
+	for _, tt := range tests {
+		if got := Hex(tt.color); got != tt.expected {
+			t.Errorf("Hex(%d) = %q, want %q", tt.color, got, tt.expected)
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 type Config struct {
 	TablePadChar       rune
+	TableMaxWidth      int
+	TableBorderStyle   int
 	AutoFloatPrecision int
 	TimeFormat,
 	TimeFormat12hr,
@@ -45,6 +47,8 @@ type Config struct {
 	ColorFile,
 	ColorError,
 	ColorUnitHumanReadable,
+	ColorTreeLines,
+	ColorProgressUnknown,
 	ColorIndicator,
 	ColorIndicatorInfo,
 	ColorIndicatorOK,
@@ -66,6 +70,8 @@ type Config struct {
 	SplitOnNewLine,
 	CheckIfURLIsAlive bool
 	ProgressBarWidth int
+	ProgressLayout   string
+	WrapWidth        int
 	Indicators       map[rune]*indicator.Indicator
 	ReverseDNSCache  map[string]string
 	CreatedAt        time.Time
@@ -78,6 +84,8 @@ func (c *Config) AddIndicator(id rune, value string, color int) {
 func NewDefaultConfig() *Config {
 	c := &Config{
 		TablePadChar:             ' ',
+		TableMaxWidth:            0, // 0 disables the limit, -1 uses the terminal width
+		TableBorderStyle:         0, // light box-drawing characters
 		AutoFloatPrecision:       2,
 		TimeFormat:               "15:04:05",
 		TimeFormat12hr:           "03:04:05pm",
@@ -114,6 +122,8 @@ func NewDefaultConfig() *Config {
 		ColorFile:                colormap.LightBlue,
 		ColorError:               colormap.Red,
 		ColorUnitHumanReadable:   160,
+		ColorTreeLines:           colormap.DarkGray,
+		ColorProgressUnknown:     colormap.DarkGray,
 		ColorIndicator:           colormap.DarkGray,
 		ColorIndicatorInfo:       colormap.LightBlue,
 		ColorIndicatorOK:         colormap.OliveGreen,
@@ -135,6 +145,8 @@ func NewDefaultConfig() *Config {
 		SplitOnNewLine:           false, // false by default to not break old behavior
 		CheckIfURLIsAlive:        true,  // true by default to not break old behavior
 		ProgressBarWidth:         20,
+		ProgressLayout:           "{bar} {count} {rate} ETA {eta}",
+		WrapWidth:                0, // 0 disables wrapping, -1 uses the terminal width
 		Indicators:               map[rune]*indicator.Indicator{},
 		ReverseDNSCache:          map[string]string{},
 		CreatedAt:                time.Now(),
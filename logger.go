@@ -13,34 +13,76 @@ type Indicator = indicator.Indicator
 type TraceLine = logger.TraceLine
 type TableColumn = logger.TableColumn
 type Table = logger.Table
+type TableSortKey = logger.TableSortKey
+type TableCSVOptions = logger.TableCSVOptions
+type TableAggregate = logger.TableAggregate
+type TableAggregateFunc = logger.TableAggregateFunc
+type TableStream = logger.TableStream
+type TableSpan = logger.TableSpan
+type TableDiff = logger.TableDiff
+type Tree = logger.Tree
+type TreeNode = logger.TreeNode
+type Panel = logger.Panel
+type Section = logger.Section
+type LogGroup = logger.LogGroup
+type ProgressManager = logger.ProgressManager
+type ProgressTask = logger.ProgressTask
+type ProgressTracker = logger.ProgressTracker
 type GError = logger.GError
 type GErrorRegistry = logger.GErrorRegistry
+type StreamHandler = logger.StreamHandler
 
 const (
-	PAD_LEFT     = logger.PAD_LEFT
-	PAD_CENTER   = logger.PAD_CENTER
-	PAD_RIGHT    = logger.PAD_RIGHT
-	DarkBlue     = colormap.DarkBlue
-	Blue         = colormap.Blue
-	DarkGreen    = colormap.DarkGreen
-	LightBlue    = colormap.LightBlue
-	OliveGreen   = colormap.OliveGreen
-	Green        = colormap.Green
-	Cyan         = colormap.Cyan
-	Purple       = colormap.Purple
-	DarkOrange   = colormap.DarkOrange
-	DarkYellow   = colormap.DarkYellow
-	Lime         = colormap.Lime
-	DarkRed      = colormap.DarkRed
-	Red          = colormap.Red
-	Pink         = colormap.Pink
-	Orange       = colormap.Orange
-	Yellow       = colormap.Yellow
-	BrightYellow = colormap.BrightYellow
-	DarkGray     = colormap.DarkGray
-	MediumGray   = colormap.MediumGray
-	Gray         = colormap.Gray
-	White        = colormap.White
+	PAD_LEFT                  = logger.PAD_LEFT
+	PAD_CENTER                = logger.PAD_CENTER
+	PAD_RIGHT                 = logger.PAD_RIGHT
+	OVERFLOW_WRAP             = logger.OVERFLOW_WRAP
+	OVERFLOW_TRUNCATE         = logger.OVERFLOW_TRUNCATE
+	TABLE_WIDTH_AUTO          = logger.TABLE_WIDTH_AUTO
+	WRAP_WIDTH_AUTO           = logger.WRAP_WIDTH_AUTO
+	WRAP_MIN_WIDTH            = logger.WRAP_MIN_WIDTH
+	VALIGN_TOP                = logger.VALIGN_TOP
+	VALIGN_MIDDLE             = logger.VALIGN_MIDDLE
+	VALIGN_BOTTOM             = logger.VALIGN_BOTTOM
+	BORDER_LIGHT              = logger.BORDER_LIGHT
+	BORDER_HEAVY              = logger.BORDER_HEAVY
+	BORDER_DOUBLE             = logger.BORDER_DOUBLE
+	BORDER_ROUNDED            = logger.BORDER_ROUNDED
+	BORDER_ASCII              = logger.BORDER_ASCII
+	BORDER_MARKDOWN           = logger.BORDER_MARKDOWN
+	BORDER_NONE               = logger.BORDER_NONE
+	BORDER_COMPACT            = logger.BORDER_COMPACT
+	CSV_DURATION_STRING       = logger.CSV_DURATION_STRING
+	CSV_DURATION_SECONDS      = logger.CSV_DURATION_SECONDS
+	CSV_DURATION_MILLISECONDS = logger.CSV_DURATION_MILLISECONDS
+	TABLE_DIFF_ADDED          = logger.TABLE_DIFF_ADDED
+	TABLE_DIFF_REMOVED        = logger.TABLE_DIFF_REMOVED
+	TABLE_DIFF_CHANGED        = logger.TABLE_DIFF_CHANGED
+	TABLE_DIFF_UNCHANGED      = logger.TABLE_DIFF_UNCHANGED
+	PROGRESS_SMOOTHING        = logger.PROGRESS_SMOOTHING
+	PROGRESS_SAMPLE_INTERVAL  = logger.PROGRESS_SAMPLE_INTERVAL
+	SECTION_DEFAULT_WIDTH     = logger.SECTION_DEFAULT_WIDTH
+	DarkBlue                  = colormap.DarkBlue
+	Blue                      = colormap.Blue
+	DarkGreen                 = colormap.DarkGreen
+	LightBlue                 = colormap.LightBlue
+	OliveGreen                = colormap.OliveGreen
+	Green                     = colormap.Green
+	Cyan                      = colormap.Cyan
+	Purple                    = colormap.Purple
+	DarkOrange                = colormap.DarkOrange
+	DarkYellow                = colormap.DarkYellow
+	Lime                      = colormap.Lime
+	DarkRed                   = colormap.DarkRed
+	Red                       = colormap.Red
+	Pink                      = colormap.Pink
+	Orange                    = colormap.Orange
+	Yellow                    = colormap.Yellow
+	BrightYellow              = colormap.BrightYellow
+	DarkGray                  = colormap.DarkGray
+	MediumGray                = colormap.MediumGray
+	Gray                      = colormap.Gray
+	White                     = colormap.White
 )
 
 var (
@@ -73,9 +115,63 @@ var (
 	NewTableColumnCenter       = logger.NewTableColumnCenter
 	NewTable                   = logger.NewTable
 
+	// NewTableFromStructs creates a table from a slice of structs, columns can be configured with `table` struct tags.
+	NewTableFromStructs      = logger.NewTableFromStructs
+	RegisterTableHighlighter = logger.RegisterTableHighlighter
+
+	// NewTableFromCSV, NewTableFromTSV, NewTableFromJSON and NewTableFromYAML create tables from data files,
+	// column types are inferred so values are highlighted according to their type.
+	NewTableFromCSV  = logger.NewTableFromCSV
+	NewTableFromTSV  = logger.NewTableFromTSV
+	NewTableFromJSON = logger.NewTableFromJSON
+	NewTableFromYAML = logger.NewTableFromYAML
+
+	// Aggregates for table footers, see TableColumn.SetAggregate.
+	AggregateSum   = logger.AggregateSum
+	AggregateAvg   = logger.AggregateAvg
+	AggregateMin   = logger.AggregateMin
+	AggregateMax   = logger.AggregateMax
+	AggregateCount = logger.AggregateCount
+	AggregateText  = logger.AggregateText
+
+	// NewTableAggregate creates a custom aggregate, see TableAggregate.
+	NewTableAggregate = logger.NewTableAggregate
+
+	// NewTableStream creates a table that prints its rows as they are pushed.
+	NewTableStream = logger.NewTableStream
+
+	// NewTableSpan creates a table value that spans multiple columns, see Table.AddRow.
+	NewTableSpan = logger.NewTableSpan
+
+	// DiffTables compares two snapshots of a table, see TableDiff.
+	DiffTables = logger.DiffTables
+
+	// Constructors for trees, see Tree.
+	NewTree         = logger.NewTree
+	NewTreeNode     = logger.NewTreeNode
+	NewTreeFromMap  = logger.NewTreeFromMap
+	NewTreeFromDir  = logger.NewTreeFromDir
+	NewTreeFromJSON = logger.NewTreeFromJSON
+
+	// NewPanel creates a box around lines of text, NewSection a horizontal rule with a heading.
+	NewPanel   = logger.NewPanel
+	NewSection = logger.NewSection
+
+	// NewProgressManager creates a manager that draws progress bars for multiple tasks.
+	NewProgressManager = logger.NewProgressManager
+
+	// NewProgressTracker creates a progress bar with counts, rate and ETA.
+	NewProgressTracker = logger.NewProgressTracker
+
+	// NewTableCSVOptions returns the default options for CSV exports of tables.
+	NewTableCSVOptions = logger.NewTableCSVOptions
+
 	NewGError         = logger.NewGError
 	NewGErrorRegistry = logger.NewGErrorRegistry
 
+	// NewStreamHandler creates an http.Handler that streams the output of all loggers live to browsers (SSE) and `curl`.
+	NewStreamHandler = logger.NewStreamHandler
+
 	// MapColor translates `index` from glog's color table to the corresponding ANSI color index.
 	// Glog uses its own color table to make smooth (automated) color transitions easier to implement.
 	MapColor = colormap.MapColor
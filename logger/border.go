@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"strings"
+
+	"github.com/toxyl/glog/utils"
+)
+
+const (
+	BORDER_LIGHT    = iota // ┌─┬─┐
+	BORDER_HEAVY           // ┏━┳━┓
+	BORDER_DOUBLE          // ╔═╦═╗
+	BORDER_ROUNDED         // ╭─┬─╮
+	BORDER_ASCII           // +-+-+
+	BORDER_MARKDOWN        // | a | b |, header separated by |---|---|
+	BORDER_NONE            // no borders at all, columns separated by spaces
+	BORDER_COMPACT         // no outer borders, columns separated by spaces, header underlined
+)
+
+// borderLine defines a horizontal line: left edge, fill, junction between columns and right edge.
+// Lines with an empty fill are not drawn. The fill must be exactly one column wide.
+type borderLine struct {
+	left, fill, junction, right string
+}
+
+// line draws the horizontal line for columns of the given widths.
+// If the border has no outer edges (`outer` is false), the edges and the padding next to them are omitted.
+func (l borderLine) line(widths []int, outer bool) string {
+	junctions := make([]string, max(len(widths)-1, 0))
+	for i := range junctions {
+		junctions[i] = l.junction
+	}
+	return l.lineWith(widths, junctions, outer)
+}
+
+// lineWith draws the horizontal line like line, but with the given junction between each pair of columns.
+func (l borderLine) lineWith(widths []int, junctions []string, outer bool) string {
+	res := ""
+	for i, w := range widths {
+		if i > 0 {
+			res += l.fill + junctions[i-1] + l.fill
+		}
+		res += strings.Repeat(l.fill, w)
+	}
+	if outer {
+		res = l.left + l.fill + res + l.fill + l.right
+	}
+	return res
+}
+
+// borderSet defines all characters needed to draw the borders of a table.
+type borderSet struct {
+	top, header, separator, bottom borderLine
+	left, middle, right            string // vertical borders of rows
+}
+
+// outer returns true if the border has left and right edges.
+func (b *borderSet) outer() bool {
+	return b.left != "" || b.right != ""
+}
+
+// width returns the total width of a table with columns of the given widths.
+func (b *borderSet) width(widths []int) int {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	if b.outer() {
+		total += utils.StringWidth(b.left) + utils.StringWidth(b.right) + 2
+	}
+	if len(widths) > 1 {
+		total += (len(widths) - 1) * (utils.StringWidth(b.middle) + 2)
+	}
+	return total
+}
+
+var borderSets = map[int]*borderSet{
+	BORDER_LIGHT: {
+		top:       borderLine{"┌", "─", "┬", "┐"},
+		header:    borderLine{"├", "─", "┼", "┤"},
+		separator: borderLine{"├", "─", "┼", "┤"},
+		bottom:    borderLine{"└", "─", "┴", "┘"},
+		left:      "│", middle: "│", right: "│",
+	},
+	BORDER_HEAVY: {
+		top:       borderLine{"┏", "━", "┳", "┓"},
+		header:    borderLine{"┣", "━", "╋", "┫"},
+		separator: borderLine{"┣", "━", "╋", "┫"},
+		bottom:    borderLine{"┗", "━", "┻", "┛"},
+		left:      "┃", middle: "┃", right: "┃",
+	},
+	BORDER_DOUBLE: {
+		top:       borderLine{"╔", "═", "╦", "╗"},
+		header:    borderLine{"╠", "═", "╬", "╣"},
+		separator: borderLine{"╠", "═", "╬", "╣"},
+		bottom:    borderLine{"╚", "═", "╩", "╝"},
+		left:      "║", middle: "║", right: "║",
+	},
+	BORDER_ROUNDED: {
+		top:       borderLine{"╭", "─", "┬", "╮"},
+		header:    borderLine{"├", "─", "┼", "┤"},
+		separator: borderLine{"├", "─", "┼", "┤"},
+		bottom:    borderLine{"╰", "─", "┴", "╯"},
+		left:      "│", middle: "│", right: "│",
+	},
+	BORDER_ASCII: {
+		top:       borderLine{"+", "-", "+", "+"},
+		header:    borderLine{"+", "-", "+", "+"},
+		separator: borderLine{"+", "-", "+", "+"},
+		bottom:    borderLine{"+", "-", "+", "+"},
+		left:      "|", middle: "|", right: "|",
+	},
+	BORDER_MARKDOWN: {
+		header:    borderLine{"|", "-", "|", "|"},
+		separator: borderLine{"|", "-", "|", "|"},
+		left:      "|", middle: "|", right: "|",
+	},
+	BORDER_NONE: {
+		middle: " ",
+	},
+	BORDER_COMPACT: {
+		// the edges are only used next to separator cells within rows
+		header:    borderLine{" ", "─", " ", " "},
+		separator: borderLine{" ", "─", " ", " "},
+		middle:    " ",
+	},
+}
+
+// getBorderSet returns the characters of the given border style, unknown styles fall back to BORDER_LIGHT.
+func getBorderSet(style int) *borderSet {
+	if b, ok := borderSets[style]; ok {
+		return b
+	}
+	return borderSets[BORDER_LIGHT]
+}
@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/toxyl/glog/utils"
+)
+
+func TestBorderStyles(t *testing.T) {
+	tests := []struct {
+		style    int
+		expected []string
+	}{
+		{
+			style: BORDER_LIGHT,
+			expected: []string{
+				"┌──────┬──────┐",
+				"│ Name │ Size │",
+				"├──────┼──────┤",
+				"│ a    │    1 │",
+				"├──────┼──────┤",
+				"│ bb   │   22 │",
+				"└──────┴──────┘",
+			},
+		},
+		{
+			style: BORDER_HEAVY,
+			expected: []string{
+				"┏━━━━━━┳━━━━━━┓",
+				"┃ Name ┃ Size ┃",
+				"┣━━━━━━╋━━━━━━┫",
+				"┃ a    ┃    1 ┃",
+				"┣━━━━━━╋━━━━━━┫",
+				"┃ bb   ┃   22 ┃",
+				"┗━━━━━━┻━━━━━━┛",
+			},
+		},
+		{
+			style: BORDER_DOUBLE,
+			expected: []string{
+				"╔══════╦══════╗",
+				"║ Name ║ Size ║",
+				"╠══════╬══════╣",
+				"║ a    ║    1 ║",
+				"╠══════╬══════╣",
+				"║ bb   ║   22 ║",
+				"╚══════╩══════╝",
+			},
+		},
+		{
+			style: BORDER_ROUNDED,
+			expected: []string{
+				"╭──────┬──────╮",
+				"│ Name │ Size │",
+				"├──────┼──────┤",
+				"│ a    │    1 │",
+				"├──────┼──────┤",
+				"│ bb   │   22 │",
+				"╰──────┴──────╯",
+			},
+		},
+		{
+			style: BORDER_ASCII,
+			expected: []string{
+				"+------+------+",
+				"| Name | Size |",
+				"+------+------+",
+				"| a    |    1 |",
+				"+------+------+",
+				"| bb   |   22 |",
+				"+------+------+",
+			},
+		},
+		{
+			style: BORDER_MARKDOWN, // no top and bottom border
+			expected: []string{
+				"| Name | Size |",
+				"|------|------|",
+				"| a    |    1 |",
+				"|------|------|",
+				"| bb   |   22 |",
+			},
+		},
+		{
+			style: BORDER_NONE, // no lines at all, separator rows are omitted
+			expected: []string{
+				"Name   Size",
+				"a         1",
+				"bb       22",
+			},
+		},
+		{
+			style: BORDER_COMPACT, // no outer borders, only horizontal lines
+			expected: []string{
+				"Name   Size",
+				"───── ─────",
+				"a         1",
+				"───── ─────",
+				"bb       22",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tbl := NewTable(NewTableColumnLeft("Name"), NewTableColumnRight("Size")).
+			SetBorderStyle(tt.style).
+			AddRow("a", 1).
+			AddSeparator().
+			AddRow("bb", 22)
+		got := plainLines(tbl.render(0, true))
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("style %d: render() = %q, want %q", tt.style, got, tt.expected)
+		}
+		width := getBorderSet(tt.style).width(tbl.columnWidths(0, nil))
+		for _, line := range got {
+			if w := utils.StringWidth(line); w != width {
+				t.Errorf("style %d: line %q is %d columns wide, want %d", tt.style, line, w, width)
+			}
+		}
+	}
+}
+
+func TestGetBorderSetFallback(t *testing.T) {
+	if got := getBorderSet(-42); got != borderSets[BORDER_LIGHT] {
+		t.Errorf("getBorderSet(-42) = %+v, want BORDER_LIGHT", got)
+	}
+}
@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/colorizers"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// LogGroup is a scope of a logger, see Logger.Group.
+type LogGroup struct {
+	logger  *Logger
+	title   string
+	start   time.Time
+	elapsed bool
+	ended   bool
+}
+
+// Group prints `title` and indents all following messages of the logger with guides
+// until End, EndSuccess or EndError of the returned group is called.
+// Groups can be nested, e.g. deploy → host → step:
+//
+//	┌─ Deploy
+//	│  ┌─ web-01
+//	│  │  copying files
+//	│  └─ web-01 (1.2s)
+//	└─ Deploy (3.4s)
+//
+// Titles without colors are highlighted like table headers.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.ColorTreeLines`
+func (l *Logger) Group(title string) *LogGroup {
+	g := &LogGroup{
+		logger:  l,
+		title:   highlightTitle(title),
+		start:   time.Now(),
+		elapsed: true,
+	}
+	l.Blank("%s%s", ansi.Wrap("┌─ ", config.LoggerConfig.ColorTreeLines).String(), g.title)
+	l.groupsLock.Lock()
+	l.groups = append(l.groups, g)
+	l.groupsLock.Unlock()
+	return g
+}
+
+// groupGuides returns the guides that indent messages inside of groups.
+func (l *Logger) groupGuides() string {
+	l.groupsLock.Lock()
+	depth := len(l.groups)
+	l.groupsLock.Unlock()
+	if depth == 0 {
+		return ""
+	}
+	return ansi.Wrap(strings.Repeat("│  ", depth), config.LoggerConfig.ColorTreeLines).String()
+}
+
+// groupIndex returns the index of `g` in the open groups or -1 if it isn't open, the caller must hold groupsLock.
+func (l *Logger) groupIndex(g *LogGroup) int {
+	for i, open := range l.groups {
+		if open == g {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetElapsed determines whether the time since the group has been started
+// is shown when it ends. This is enabled by default.
+func (g *LogGroup) SetElapsed(show bool) *LogGroup {
+	g.elapsed = show
+	return g
+}
+
+// Elapsed returns the time since the group has been started.
+func (g *LogGroup) Elapsed() time.Duration {
+	return time.Since(g.start)
+}
+
+// end closes the group and all groups nested in it, then prints the closing line
+// with the given indicator. Groups can only be ended once.
+func (g *LogGroup) end(indicator rune, format string, a ...any) {
+	l := g.logger
+	l.groupsLock.Lock()
+	if g.ended {
+		l.groupsLock.Unlock()
+		return
+	}
+	g.ended = true
+	nested := []*LogGroup{}
+	if idx := l.groupIndex(g); idx >= 0 {
+		nested = append(nested, l.groups[idx+1:]...)
+	}
+	l.groupsLock.Unlock()
+
+	for i := len(nested) - 1; i >= 0; i-- {
+		nested[i].End() // nested groups that haven't been ended yet, innermost first
+	}
+	l.groupsLock.Lock()
+	if idx := l.groupIndex(g); idx >= 0 {
+		l.groups = l.groups[:idx]
+	}
+	l.groupsLock.Unlock()
+
+	msg := ansi.Wrap("└─ ", config.LoggerConfig.ColorTreeLines).String() + g.title
+	if format != "" {
+		msg += " " + fmt.Sprintf(format, a...)
+	}
+	if g.elapsed {
+		msg += " (" + colorizers.DurationShort(g.Elapsed().Seconds(), utils.DURATION_SCALE_AVERAGE) + ")"
+	}
+	l.write(indicator, "%s", msg)
+}
+
+// End ends the group and all groups nested in it that haven't been ended yet.
+func (g *LogGroup) End() {
+	g.end('_', "")
+}
+
+// EndSuccess ends the group like End and prints the closing line as success message,
+// followed by the given format and arguments (if any).
+func (g *LogGroup) EndSuccess(format string, a ...any) {
+	g.end('✓', format, a...)
+}
+
+// EndError ends the group like End and prints the closing line as error message,
+// followed by the given format and arguments (if any).
+func (g *LogGroup) EndError(format string, a ...any) {
+	g.end('x', format, a...)
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// newGroupLogger returns a logger without prefix and the lines it has written (safe for concurrent use).
+func newGroupLogger(t *testing.T) (*Logger, func() []string) {
+	withConfig(t, func(c *config.Config) {
+		c.ShowDateTime = false
+		c.ShowRuntimeHumanReadable = false
+		c.ShowRuntimeSeconds = false
+		c.ShowRuntimeMilliseconds = false
+		c.ShowSubsystem = false
+		c.ShowIndicator = false
+		c.WrapWidth = 0
+	})
+	lock := &sync.Mutex{}
+	lines := []string{}
+	l := NewLogger("test", 1, false, func(s string) {
+		lock.Lock()
+		defer lock.Unlock()
+		lines = append(lines, strings.TrimSpace(utils.StripANSI(s)))
+	})
+	return l, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, lines...)
+	}
+}
+
+func TestGroupNesting(t *testing.T) {
+	l, lines := newGroupLogger(t)
+	outer := l.Group("outer").SetElapsed(false)
+	l.Blank("a")
+	l.Group("inner").SetElapsed(false)
+	l.Blank("b")
+	outer.End() // also ends inner
+	outer.End() // groups can only be ended once
+	l.Blank("c")
+
+	expected := []string{
+		"┌─ outer",
+		"│  a",
+		"│  ┌─ inner",
+		"│  │  b",
+		"│  └─ inner",
+		"└─ outer",
+		"c",
+	}
+	if got := lines(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("output = %q, want %q", got, expected)
+	}
+}
+
+// TestGroupConcurrent is meant to be run with -race: groups are opened and ended
+// while other goroutines write messages with the same logger.
+func TestGroupConcurrent(t *testing.T) {
+	l, lines := newGroupLogger(t)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				l.Group("group").End()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				l.Blank("message")
+				_ = l.prefixWidth('_')
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(l.groups) != 0 {
+		t.Errorf("%d groups are still open", len(l.groups))
+	}
+	if got := len(lines()); got != 8*50*3 {
+		t.Errorf("%d lines written, want %d", got, 8*50*3)
+	}
+}
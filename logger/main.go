@@ -7,14 +7,21 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/toxyl/glog/ansi"
 	"github.com/toxyl/glog/colorizers"
 	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/terminal"
 	"github.com/toxyl/glog/utils"
 )
 
+const (
+	WRAP_WIDTH_AUTO = -1 // wrap messages at the width of the terminal
+	WRAP_MIN_WIDTH  = 10 // messages aren't wrapped if less columns than this are left next to the prefix
+)
+
 type Logger struct {
 	ID         string
 	color      int
@@ -24,6 +31,9 @@ type Logger struct {
 	file       string
 	fileColor  string
 	onMessage  func(string)
+	groups     []*LogGroup   // open groups, innermost last
+	groupsLock *sync.Mutex   // guards groups, which are used by all goroutines that write with the logger
+	progress   *ProgressTask // task that shows Logger.Progress while a progress manager is active, guarded by progressMu
 }
 
 // EnableTrace enables trace mode for the logger with the given trace level.
@@ -74,34 +84,9 @@ func (l *Logger) DisableColorLog() {
 	l.fileColor = ""
 }
 
-// write logs a message to the console or file with an optional indicator,
-// and applies various formatting options as specified in the logger's configuration.
-//
-// If a progress indicator ('p') is specified, it will be displayed as a progress bar
-// and continuously replaced on the same line using ANSI escape sequences.
-//
-// The 'format' parameter is a string that can contain verbs, as specified by the fmt package,
-// and the 'a' parameter provides the corresponding arguments for each verb.
-//
-// If a message handler function was specified during logger creation, the formatted message
-// will be passed to it instead of being printed to the console or file.
-//
-// If the logger is configured to use colors, the message will include ANSI escape sequences
-// to apply the appropriate colors for the message elements.
-//
-// If a file has been specified for the logger, the message will be appended to the file.
-// If a color file has also been specified, the message with color codes will be appended to that file.
-// If the logger's configuration disables colors, any color codes will be stripped from the message.
-//
-// Related config setting(s):
-//
-//   - LoggerConfig.ShowIndicator
-//   - LoggerConfig.ShowDateTime
-//   - LoggerConfig.ShowRuntimeSeconds
-//   - LoggerConfig.ShowRuntimeMilliseconds
-//   - LoggerConfig.ColorsDisabled
-//   - LoggerConfig.Indicators
-func (l *Logger) write(indicator rune, format string, a ...any) {
+// prefix returns what is printed in front of messages with the given indicator,
+// including the trailing space.
+func (l *Logger) prefix(indicator rune) string {
 	prefix := ""
 
 	if config.LoggerConfig.ShowIndicator {
@@ -125,16 +110,118 @@ func (l *Logger) write(indicator rune, format string, a ...any) {
 	if config.LoggerConfig.ShowSubsystem {
 		prefix = fmt.Sprintf("%s %s: ", prefix, ansi.Wrap(fmt.Sprintf("%-16s", l.ID), l.color).String())
 	}
+	prefix += " "
+	return prefix
+}
 
-	msg := fmt.Sprintf(format, a...)
+// prefixWidth returns the number of terminal columns the prefix of messages with the given indicator occupies.
+func (l *Logger) prefixWidth(indicator rune) int {
+	return utils.StringWidth(l.prefix(indicator)) + utils.StringWidth(l.groupGuides())
+}
+
+// wrapWidth returns the width messages are wrapped at or 0 if they aren't wrapped.
+//
+// Related config setting(s):
+//
+//   - LoggerConfig.WrapWidth
+func wrapWidth() int {
+	w := config.LoggerConfig.WrapWidth
+	if w == WRAP_WIDTH_AUTO {
+		w = terminal.Width()
+	}
+	return max(w, 0)
+}
+
+// compose prepends the prefix for the given indicator (datetime, runtime, subsystem, etc.) to `msg`.
+//
+// If wrapping is enabled, lines longer than the wrap width are wrapped
+// and continuation lines are indented to align with the start of the message.
+// If the prefix leaves less than WRAP_MIN_WIDTH columns, lines are not wrapped at all.
+// The progress indicator ('p') is never wrapped as it has to stay on a single line,
+// pre-formatted output (tables, panels, trees, ...) isn't wrapped if `wrap` is false.
+// Inside of groups (see Logger.Group) all lines are indented with guides.
+//
+// Related config setting(s):
+//
+//   - LoggerConfig.ColorTreeLines
+//   - LoggerConfig.ShowIndicator
+//   - LoggerConfig.ShowDateTime
+//   - LoggerConfig.ShowRuntimeHumanReadable
+//   - LoggerConfig.ShowRuntimeSeconds
+//   - LoggerConfig.ShowRuntimeMilliseconds
+//   - LoggerConfig.ShowSubsystem
+//   - LoggerConfig.SplitOnNewLine
+//   - LoggerConfig.WrapWidth
+//   - LoggerConfig.Indicators
+func (l *Logger) compose(indicator rune, msg string, wrap bool) string {
+	prefix := l.prefix(indicator)
+	guides := l.groupGuides()
+
+	lines := []string{msg}
 	if config.LoggerConfig.SplitOnNewLine {
-		res := []string{}
-		for _, ln := range strings.Split(msg, "\n") {
-			res = append(res, prefix+" "+ln)
+		lines = strings.Split(msg, "\n")
+	}
+
+	indent := utils.StringWidth(prefix)
+	available := wrapWidth() - indent - utils.StringWidth(guides)
+	wrap = wrap && available >= WRAP_MIN_WIDTH && indicator != 'p'
+
+	res := []string{}
+	for _, ln := range lines {
+		if !wrap {
+			res = append(res, prefix+guides+ln)
+			continue
 		}
-		msg = strings.Join(res, "\n")
-	} else {
-		msg = prefix + " " + msg
+		for i, wl := range utils.Wrap(ln, available) {
+			if i == 0 {
+				res = append(res, prefix+guides+wl)
+			} else {
+				res = append(res, strings.Repeat(" ", indent)+guides+wl)
+			}
+		}
+	}
+	return strings.Join(res, "\n")
+}
+
+// write logs a message to the console or file with an optional indicator,
+// and applies various formatting options as specified in the logger's configuration.
+//
+// If a progress indicator ('p') is specified, it will be displayed as a progress bar
+// and continuously replaced on the same line using ANSI escape sequences.
+//
+// The 'format' parameter is a string that can contain verbs, as specified by the fmt package,
+// and the 'a' parameter provides the corresponding arguments for each verb.
+//
+// If a message handler function was specified during logger creation, the formatted message
+// will be passed to it instead of being printed to the console or file.
+//
+// If the logger is configured to use colors, the message will include ANSI escape sequences
+// to apply the appropriate colors for the message elements.
+//
+// If a file has been specified for the logger, the message will be appended to the file.
+// If a color file has also been specified, the message with color codes will be appended to that file.
+// If the logger's configuration disables colors, any color codes will be stripped from the message.
+//
+// Related config setting(s):
+//
+//   - LoggerConfig.ColorsDisabled
+//
+// See also:
+//   - Logger.compose
+func (l *Logger) write(indicator rune, format string, a ...any) {
+	l.output(indicator, l.compose(indicator, fmt.Sprintf(format, a...), true))
+}
+
+// writeBlock logs a line of pre-formatted output (tables, panels, trees, ...) like Logger.Blank,
+// but never wraps it, box drawings would fall apart otherwise.
+func (l *Logger) writeBlock(line string) {
+	l.output('_', l.compose('_', line, false))
+}
+
+// output sends a composed message to the streams and the message handler or console and files (see Logger.write).
+func (l *Logger) output(indicator rune, msg string) {
+	if indicator != 'p' {
+		streams.publish(l.ID, indicator, msg)
 	}
 
 	if indicator == 'p' {
@@ -189,7 +276,7 @@ func (l *Logger) write(indicator rune, format string, a ...any) {
 		}
 	}
 
-	fmt.Print(msg)
+	printOutput(msg)
 }
 
 // auto prints a message using the given indicator, but will first run all arguments
@@ -362,6 +449,9 @@ func (l *Logger) KeyValueTable(data map[string]any) {
 // Use the methods ProgressSuccess and ProgressError to end a progress.
 // This will print a message of the corresponding type and advance to the next line.
 //
+// While a ProgressManager owns the terminal, the bar is shown as a task of that manager instead,
+// so it doesn't collide with the manager's bars. ProgressSuccess and ProgressError finish the task.
+//
 // It uses the format string and arguments to print additional information
 // alongside the progress bar. The progress parameter should be a float between
 // 0 and 1, representing the progress percentage as a decimal. The progress bar
@@ -374,6 +464,10 @@ func (l *Logger) KeyValueTable(data map[string]any) {
 //   - Logger.ProgressSuccess
 //   - Logger.ProgressError
 func (l *Logger) Progress(progress float64, format string, a ...any) {
+	if t := l.managedProgress(true); t != nil {
+		t.Update(progress, format, a...)
+		return
+	}
 	a = append([]any{ProgressBar(progress, config.LoggerConfig.ProgressBarWidth)}, a...)
 	l.write('p', "%s "+format, a...)
 }
@@ -394,6 +488,10 @@ func (l *Logger) Progress(progress float64, format string, a ...any) {
 //   - Logger.Progress
 //   - Logger.ProgressError
 func (l *Logger) ProgressSuccess(progress float64, format string, a ...any) {
+	if t := l.managedProgress(false); t != nil {
+		t.Success(progress, format, a...)
+		return
+	}
 	a = append([]any{ansi.ClearToEOL().String(), ProgressBar(progress, config.LoggerConfig.ProgressBarWidth)}, a...)
 	l.Success("%s%s "+format, a...)
 }
@@ -414,6 +512,10 @@ func (l *Logger) ProgressSuccess(progress float64, format string, a ...any) {
 //   - Logger.Progress
 //   - Logger.ProgressSuccess
 func (l *Logger) ProgressError(progress float64, format string, a ...any) {
+	if t := l.managedProgress(false); t != nil {
+		t.Error(progress, format, a...)
+		return
+	}
 	a = append([]any{ansi.ClearToEOL().String(), ProgressBar(progress, config.LoggerConfig.ProgressBarWidth)}, a...)
 	l.Error("%s%s "+format, a...)
 }
@@ -421,40 +523,7 @@ func (l *Logger) ProgressError(progress float64, format string, a ...any) {
 // QuestionInline prints a question message without adding a newline, allowing for inline user input.
 // This method uses the same visual styling as Question but doesn't advance to the next line.
 func (l *Logger) QuestionInline(format string, a ...any) {
-	prefix := ""
-
-	if config.LoggerConfig.ShowIndicator {
-		if vi, ok := config.LoggerConfig.Indicators['?']; ok {
-			prefix = ansi.Wrap(vi.Value, vi.Color).String()
-		}
-	}
-
-	if config.LoggerConfig.ShowRuntimeHumanReadable {
-		prefix = fmt.Sprintf("%22s %s", colorizers.RuntimeHumanReadable(), prefix)
-	}
-	if config.LoggerConfig.ShowRuntimeSeconds {
-		prefix = fmt.Sprintf("%22s s %s", colorizers.RuntimeSeconds(), prefix)
-	}
-	if config.LoggerConfig.ShowRuntimeMilliseconds {
-		prefix = fmt.Sprintf("%22s ms %s", colorizers.RuntimeMilliseconds(), prefix)
-	}
-	if config.LoggerConfig.ShowDateTime {
-		prefix = fmt.Sprintf("%s %s", colorizers.DateTime(time.Now()), prefix)
-	}
-	if config.LoggerConfig.ShowSubsystem {
-		prefix = fmt.Sprintf("%s %s: ", prefix, ansi.Wrap(fmt.Sprintf("%-16s", l.ID), l.color).String())
-	}
-
-	msg := fmt.Sprintf(format, a...)
-	if config.LoggerConfig.SplitOnNewLine {
-		res := []string{}
-		for ln := range strings.SplitSeq(msg, "\n") {
-			res = append(res, prefix+" "+ln)
-		}
-		msg = strings.Join(res, "\n")
-	} else {
-		msg = prefix + " " + msg
-	}
+	msg := l.compose('?', fmt.Sprintf(format, a...), true)
 
 	// No newline added for inline questions
 
@@ -612,6 +681,7 @@ func NewLogger(id string, color int, debugMode bool, messageHandler func(string)
 		onMessage:  messageHandler,
 		traceMode:  false,
 		traceLevel: 0,
+		groupsLock: &sync.Mutex{},
 	}
 }
 
@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// withConfig replaces the logger config with a modified copy for the duration of the test.
+func withConfig(t *testing.T, modify func(c *config.Config)) {
+	t.Helper()
+	orig := config.LoggerConfig
+	c := *orig
+	modify(&c)
+	config.LoggerConfig = &c
+	t.Cleanup(func() { config.LoggerConfig = orig })
+}
+
+func TestComposeWrap(t *testing.T) {
+	tests := []struct {
+		name      string
+		wrapWidth int
+		subsystem bool
+		msg       string
+		expected  []string
+	}{
+		{
+			name:      "wrapping disabled",
+			wrapWidth: 0,
+			msg:       "the quick brown fox jumps over the lazy dog",
+			expected:  []string{"[i] the quick brown fox jumps over the lazy dog"},
+		},
+		{
+			name:      "wrapped next to the prefix",
+			wrapWidth: 20,
+			msg:       "the quick brown fox jumps over the lazy dog",
+			expected:  []string{"[i] the quick brown", "    fox jumps over", "    the lazy dog"},
+		},
+		{
+			name:      "prefix wider than the wrap width",
+			wrapWidth: 15,
+			subsystem: true,
+			msg:       "the quick brown fox",
+			expected:  []string{"[i] test            :  the quick brown fox"},
+		},
+		{
+			name:      "less than WRAP_MIN_WIDTH columns left",
+			wrapWidth: 12,
+			msg:       "the quick brown fox",
+			expected:  []string{"[i] the quick brown fox"},
+		},
+	}
+
+	for _, tt := range tests {
+		withConfig(t, func(c *config.Config) {
+			c.ShowDateTime = false
+			c.ShowRuntimeHumanReadable = false
+			c.ShowRuntimeSeconds = false
+			c.ShowRuntimeMilliseconds = false
+			c.ShowSubsystem = tt.subsystem
+			c.ShowIndicator = true
+			c.WrapWidth = tt.wrapWidth
+		})
+		l := NewLogger("test", 1, false, nil)
+		got := strings.Split(utils.StripANSI(l.compose('i', tt.msg, true)), "\n")
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: compose(%q) = %q, want %q", tt.name, tt.msg, got, tt.expected)
+		}
+	}
+}
+
+func TestBlocksAreNotWrapped(t *testing.T) {
+	withConfig(t, func(c *config.Config) {
+		c.ShowDateTime = false
+		c.ShowRuntimeHumanReadable = false
+		c.ShowRuntimeSeconds = false
+		c.ShowRuntimeMilliseconds = false
+		c.ShowSubsystem = false
+		c.ShowIndicator = false
+		c.TableMaxWidth = 0
+		c.WrapWidth = 20
+	})
+	long := strings.Repeat("x", 30)
+	tree := NewTree("root")
+	tree.Root().Add(long)
+
+	tests := []struct {
+		name  string
+		print func(l *Logger)
+		lines []string
+	}{
+		{
+			name:  "table",
+			print: NewTable(NewTableColumnLeft("Name")).AddRow(long).Print,
+			lines: NewTable(NewTableColumnLeft("Name")).AddRow(long).render(0, true),
+		},
+		{
+			name:  "panel",
+			print: NewPanel("Title").Add("%s", long).Print,
+			lines: NewPanel("Title").Add("%s", long).Lines(),
+		},
+		{
+			name:  "tree",
+			print: tree.Print,
+			lines: tree.Lines(),
+		},
+	}
+
+	for _, tt := range tests {
+		got := []string{}
+		l := NewLogger("test", 1, false, func(msg string) {
+			for _, line := range strings.Split(strings.TrimSuffix(utils.StripANSI(msg), "\n"), "\n") {
+				got = append(got, strings.TrimSpace(line))
+			}
+		})
+		tt.print(l)
+		expected := []string{}
+		for _, line := range tt.lines {
+			expected = append(expected, utils.StripANSI(line))
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: printed %q, want %q", tt.name, got, expected)
+		}
+	}
+
+	got := []string{}
+	l := NewLogger("test", 1, false, func(msg string) { got = append(got, utils.StripANSI(msg)) })
+	l.Blank("%s", long)
+	if len(got) != 1 || strings.Count(got[0], "\n") != 2 {
+		t.Errorf("Blank(%q) = %q, want it wrapped to 2 lines", long, got)
+	}
+}
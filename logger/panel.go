@@ -0,0 +1,263 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/toxyl/glog/colorizers"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/terminal"
+	"github.com/toxyl/glog/utils"
+)
+
+const SECTION_DEFAULT_WIDTH = 80 // width of sections if the terminal width is unknown
+
+// highlightTitle highlights titles like table headers, unless they are already colored.
+func highlightTitle(title string) string {
+	if title == "" || utils.StripANSI(title) != title {
+		return title
+	}
+	return colorizers.Auto(title)
+}
+
+// terminalWidth returns the width of the terminal minus `reserved` or `fallback` if there is no terminal.
+func terminalWidth(reserved, fallback int) int {
+	w := terminal.Width()
+	if w <= 0 {
+		return fallback
+	}
+	return max(w-reserved, 1)
+}
+
+// Panel draws a box around lines of text with an optional title in the top border
+// and an optional footer in the bottom border:
+//
+//	┌─ Summary ─────────┐
+//	│ 12 files copied   │
+//	│ 3 files skipped   │
+//	└─────────── 1.2s ──┘
+//
+// Border styles without outer borders (e.g. BORDER_NONE or BORDER_MARKDOWN) draw
+// the title, content and footer without box.
+type Panel struct {
+	title       string
+	footer      string
+	lines       []string
+	width       int
+	padV, padH  int
+	borderStyle int
+}
+
+// NewPanel creates a panel with the given title (may be empty).
+// Titles without colors are highlighted like table headers.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TableBorderStyle`
+func NewPanel(title string) *Panel {
+	return &Panel{
+		title:       title,
+		padH:        1,
+		borderStyle: config.LoggerConfig.TableBorderStyle,
+	}
+}
+
+// Add adds a line of content (formatted like fmt.Sprintf), line breaks start new lines.
+// Content may contain ANSI escape sequences.
+func (p *Panel) Add(format string, a ...any) *Panel {
+	p.lines = append(p.lines, utils.SplitLines(fmt.Sprintf(format, a...))...)
+	return p
+}
+
+// SetFooter sets the text shown in the bottom border, right-aligned.
+func (p *Panel) SetFooter(footer string) *Panel {
+	p.footer = footer
+	return p
+}
+
+// SetPadding sets the number of empty lines above and below the content (`vertical`)
+// and the number of spaces left and right of it (`horizontal`). The default is 0 and 1.
+func (p *Panel) SetPadding(vertical, horizontal int) *Panel {
+	p.padV = max(vertical, 0)
+	p.padH = max(horizontal, 0)
+	return p
+}
+
+// SetWidth sets the total width of the panel, lines that are too long are wrapped.
+// Use 0 (default) to fit the content or TABLE_WIDTH_AUTO to use the width of the terminal.
+func (p *Panel) SetWidth(width int) *Panel {
+	p.width = width
+	return p
+}
+
+// SetBorderStyle sets the style of the box, see Table.SetBorderStyle.
+func (p *Panel) SetBorderStyle(style int) *Panel {
+	p.borderStyle = style
+	return p
+}
+
+// Lines returns the rendered lines of the panel.
+func (p *Panel) Lines() []string {
+	return p.render(0)
+}
+
+// Print prints the panel with the given logger.
+func (p *Panel) Print(logger *Logger) {
+	for _, line := range p.render(logger.prefixWidth('_')) {
+		logger.writeBlock(line)
+	}
+}
+
+// render renders the panel, `reserved` is subtracted from the terminal width when using TABLE_WIDTH_AUTO.
+func (p *Panel) render(reserved int) []string {
+	border := getBorderSet(p.borderStyle)
+	boxed := border.outer() && border.top.fill != "" && border.bottom.fill != ""
+	title, footer := highlightTitle(p.title), highlightTitle(p.footer)
+
+	// width of the box's edges and padding
+	edges := 0
+	if boxed {
+		edges = utils.StringWidth(border.left) + utils.StringWidth(border.right)
+	}
+	padH := p.padH
+	total := p.width
+	if total == TABLE_WIDTH_AUTO {
+		total = terminalWidth(reserved, 0)
+	}
+	if total > 0 { // the padding is reduced if the panel is too narrow for it
+		padH = min(padH, max((total-edges-1)/2, 0))
+	}
+	frame := edges + 2*padH
+	if total <= 0 { // fit the content
+		total = frame
+		content := 0
+		for _, ln := range p.lines {
+			content = max(content, utils.StringWidth(ln))
+		}
+		total += content
+		if boxed { // the title and footer are surrounded by a fill character and a space on each side
+			total = max(total, utils.StringWidth(title)+6, utils.StringWidth(footer)+6)
+		}
+	}
+	inner := max(total-frame, 1)
+	total = frame + inner
+
+	lines := []string{}
+	for _, ln := range p.lines {
+		for _, wl := range utils.Wrap(ln, inner) {
+			lines = append(lines, utils.Truncate(wl, inner, TABLE_ELLIPSIS)) // in case wide characters can't be wrapped
+		}
+	}
+	for range p.padV {
+		lines = append([]string{""}, lines...)
+		lines = append(lines, "")
+	}
+
+	res := []string{}
+	if !boxed {
+		if title != "" {
+			res = append(res, utils.Truncate(title, total, TABLE_ELLIPSIS))
+		}
+		for _, ln := range lines {
+			res = append(res, strings.TrimRight(strings.Repeat(" ", padH)+ln, " "))
+		}
+		if footer != "" {
+			res = append(res, utils.PadLeft(utils.Truncate(footer, total, TABLE_ELLIPSIS), total, ' '))
+		}
+		return res
+	}
+
+	res = append(res, borderText(border.top, total, title, false))
+	pad := strings.Repeat(" ", padH)
+	for _, ln := range lines {
+		res = append(res, border.left+pad+utils.PadRight(ln, inner, ' ')+pad+border.right)
+	}
+	return append(res, borderText(border.bottom, total, footer, true))
+}
+
+// borderText draws the horizontal line `l` with a total width of `width` and `text` embedded,
+// either near the left (`right` is false) or near the right edge. Texts that don't fit are truncated,
+// if the line is too short for a single character surrounded by spaces and fill characters, it's omitted.
+func borderText(l borderLine, width int, text string, right bool) string {
+	edges := utils.StringWidth(l.left) + utils.StringWidth(l.right)
+	fill := max(width-edges, 0)
+	if text == "" || fill < 5 {
+		return l.left + strings.Repeat(l.fill, fill) + l.right
+	}
+	text = utils.Truncate(text, fill-4, TABLE_ELLIPSIS)
+	rest := max(fill-utils.StringWidth(text)-3, 0)
+	if right {
+		return l.left + strings.Repeat(l.fill, rest) + " " + text + " " + l.fill + l.right
+	}
+	return l.left + l.fill + " " + text + " " + strings.Repeat(l.fill, rest) + l.right
+}
+
+// Section draws a horizontal rule with a centered heading to separate phases of output:
+//
+//	──────────────── Phase 2: Deploy ────────────────
+type Section struct {
+	title       string
+	width       int
+	borderStyle int
+}
+
+// NewSection creates a section with the given title (may be empty).
+// Titles without colors are highlighted like table headers.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TableBorderStyle`
+func NewSection(title string) *Section {
+	return &Section{
+		title:       title,
+		width:       TABLE_WIDTH_AUTO,
+		borderStyle: config.LoggerConfig.TableBorderStyle,
+	}
+}
+
+// SetWidth sets the width of the rule. Use TABLE_WIDTH_AUTO (default) to use the width of the terminal
+// (SECTION_DEFAULT_WIDTH if it's unknown).
+func (s *Section) SetWidth(width int) *Section {
+	s.width = width
+	return s
+}
+
+// SetBorderStyle sets the style of the rule, see Table.SetBorderStyle.
+// Styles without horizontal lines only show the title.
+func (s *Section) SetBorderStyle(style int) *Section {
+	s.borderStyle = style
+	return s
+}
+
+// String returns the rendered section.
+func (s *Section) String() string {
+	return s.render(0)
+}
+
+// Print prints the section with the given logger.
+func (s *Section) Print(logger *Logger) {
+	logger.writeBlock(s.render(logger.prefixWidth('_')))
+}
+
+// render renders the section, `reserved` is subtracted from the terminal width (or SECTION_DEFAULT_WIDTH)
+// when using TABLE_WIDTH_AUTO.
+func (s *Section) render(reserved int) string {
+	width := s.width
+	if width == TABLE_WIDTH_AUTO {
+		width = terminalWidth(reserved, max(SECTION_DEFAULT_WIDTH-reserved, 1))
+	}
+	fill := getBorderSet(s.borderStyle).header.fill
+	if fill == "" {
+		fill = " "
+	}
+	title := highlightTitle(s.title)
+	if title == "" {
+		return strings.Repeat(fill, width)
+	}
+	if width < 3 { // too narrow for the spaces around the title
+		return utils.Truncate(title, max(width, 1), TABLE_ELLIPSIS)
+	}
+	title = " " + utils.Truncate(title, width-2, TABLE_ELLIPSIS) + " "
+	rest := max(width-utils.StringWidth(title), 0)
+	return strings.TrimRight(strings.Repeat(fill, rest/2)+title+strings.Repeat(fill, rest-rest/2), " ")
+}
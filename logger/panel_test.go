@@ -0,0 +1,162 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+func TestPanelRender(t *testing.T) {
+	withConfig(t, func(c *config.Config) { c.TableBorderStyle = BORDER_LIGHT })
+
+	tests := []struct {
+		name     string
+		panel    *Panel
+		expected []string
+	}{
+		{
+			name:  "fits the content, footer on the right",
+			panel: NewPanel("Summary").Add("12 files").Add("3 skipped").SetFooter("1.2s"),
+			expected: []string{
+				"┌─ Summary ─┐",
+				"│ 12 files  │",
+				"│ 3 skipped │",
+				"└──── 1.2s ─┘",
+			},
+		},
+		{
+			name:  "fits the title",
+			panel: NewPanel("A long title").Add("x"),
+			expected: []string{
+				"┌─ A long title ─┐",
+				"│ x              │",
+				"└────────────────┘",
+			},
+		},
+		{
+			name:  "content is wrapped to the width",
+			panel: NewPanel("T").SetWidth(12).Add("one two three"),
+			expected: []string{
+				"┌─ T ──────┐",
+				"│ one two  │",
+				"│ three    │",
+				"└──────────┘",
+			},
+		},
+		{
+			name:  "title is truncated to the width",
+			panel: NewPanel("A long title").SetWidth(10).Add("x"),
+			expected: []string{
+				"┌─ A l… ─┐",
+				"│ x      │",
+				"└────────┘",
+			},
+		},
+		{
+			name:  "title is omitted and padding reduced if the panel is too narrow",
+			panel: NewPanel("T").SetPadding(0, 3).SetWidth(5).Add("ab"),
+			expected: []string{
+				"┌───┐",
+				"│ a │",
+				"│ b │",
+				"└───┘",
+			},
+		},
+		{
+			name:  "wide characters that can't be wrapped are truncated",
+			panel: NewPanel("").SetWidth(5).Add("日本"),
+			expected: []string{
+				"┌───┐",
+				"│ … │",
+				"│ … │",
+				"└───┘",
+			},
+		},
+		{
+			name:  "vertical padding",
+			panel: NewPanel("T").SetPadding(1, 0).Add("ab"),
+			expected: []string{
+				"┌─ T ─┐",
+				"│     │",
+				"│ab   │",
+				"│     │",
+				"└─────┘",
+			},
+		},
+		{
+			name:  "without box, title and footer are truncated",
+			panel: NewPanel("A long title").SetBorderStyle(BORDER_NONE).SetWidth(6).Add("content").SetFooter("long footer"),
+			expected: []string{
+				"A lon…",
+				" cont",
+				" ent",
+				"long …",
+			},
+		},
+		{
+			name:  "sections in panels",
+			panel: NewPanel("Log").Add("%s", NewSection("Phase 1").SetWidth(13).String()).Add("ok"),
+			expected: []string{
+				"┌─ Log ─────────┐",
+				"│ ── Phase 1 ── │",
+				"│ ok            │",
+				"└───────────────┘",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := plainLines(tt.panel.Lines()); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: Lines() = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestSectionRender(t *testing.T) {
+	withConfig(t, func(c *config.Config) { c.TableBorderStyle = BORDER_LIGHT })
+
+	tests := []struct {
+		name     string
+		section  *Section
+		expected string
+	}{
+		{name: "centered title", section: NewSection("Deploy").SetWidth(20), expected: "────── Deploy ──────"},
+		{name: "without title", section: NewSection("").SetWidth(5), expected: "─────"},
+		{name: "truncated title", section: NewSection("Deploy").SetWidth(7), expected: " Depl…"},
+		{name: "too narrow for spaces", section: NewSection("Deploy").SetWidth(2), expected: "D…"},
+		{name: "style without lines", section: NewSection("Deploy").SetWidth(12).SetBorderStyle(BORDER_NONE), expected: "   Deploy"},
+		{name: "heavy style", section: NewSection("Deploy").SetWidth(12).SetBorderStyle(BORDER_HEAVY), expected: "━━ Deploy ━━"},
+	}
+
+	for _, tt := range tests {
+		if got := utils.StripANSI(tt.section.String()); got != tt.expected {
+			t.Errorf("%s: String() = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestSectionInNestedGroups(t *testing.T) {
+	l, lines := newGroupLogger(t)
+	outer := l.Group("outer").SetElapsed(false)
+	inner := l.Group("inner").SetElapsed(false)
+	NewSection("Deploy").Print(l)
+	inner.End()
+	outer.End()
+
+	found := false
+	for _, line := range lines() {
+		if !strings.Contains(line, "Deploy") {
+			continue
+		}
+		found = true
+		if w := utils.StringWidth(line); w > SECTION_DEFAULT_WIDTH {
+			t.Errorf("section %q is %d columns wide, want at most %d including the guides", line, w, SECTION_DEFAULT_WIDTH)
+		}
+	}
+	if !found {
+		t.Errorf("lines %q don't contain the section", lines())
+	}
+}
@@ -0,0 +1,270 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/colorizers"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/terminal"
+	"github.com/toxyl/glog/utils"
+)
+
+var (
+	progressMu     sync.Mutex       // serializes terminal output while a progress manager is active
+	activeProgress *ProgressManager // the manager that currently owns the lines below the output
+)
+
+// printOutput prints `msg` to stdout, progress bars of the active manager are removed
+// before and drawn again after it, so that messages appear above the bars.
+func printOutput(msg string) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	if m := activeProgress; m != nil {
+		m.clear()
+		fmt.Print(msg)
+		m.draw()
+		return
+	}
+	fmt.Print(msg)
+}
+
+// ProgressTask is a task with a progress bar, see ProgressManager.Add.
+type ProgressTask struct {
+	manager  *ProgressManager
+	logger   *Logger // prints the task, the manager's logger unless the task shows Logger.Progress
+	label    string
+	progress float64
+	message  string
+	custom   bool // the message is the whole line (e.g. a rendered ProgressTracker), no bar or label is added
+	done     bool
+}
+
+// ProgressManager renders progress bars for multiple tasks, e.g. concurrent downloads,
+// in a block of lines at the bottom of the terminal. The block is redrawn whenever
+// a task is updated and messages of all loggers are printed above it.
+// Finished tasks are printed like Logger.ProgressSuccess and Logger.ProgressError
+// and removed from the block.
+//
+// All methods are safe for concurrent use. Only one manager can own the terminal at a time,
+// it releases it when all its tasks have finished (or Stop is called). Tasks of other managers,
+// and all tasks if stdout is not a terminal or the logger uses a message handler,
+// are not drawn, they are only printed when they finish.
+// While a manager owns the terminal, Logger.Progress and ProgressTracker.Print are shown as a task of that manager
+// (see Logger.Progress).
+//
+// Example:
+//
+//	pm := glog.NewProgressManager(log)
+//	for _, f := range files {
+//		task := pm.Add(f)
+//		go func() {
+//			// ...
+//			task.Update(0.5, "%d bytes", n)
+//			// ...
+//			task.Success(1, "done")
+//		}()
+//	}
+type ProgressManager struct {
+	logger *Logger
+	tasks  []*ProgressTask
+	drawn  int // number of lines currently on screen
+}
+
+// NewProgressManager creates a progress manager that prints with the given logger.
+func NewProgressManager(logger *Logger) *ProgressManager {
+	return &ProgressManager{logger: logger}
+}
+
+// Add adds a task with the given label and draws its progress bar.
+func (m *ProgressManager) Add(label string) *ProgressTask {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	return m.add(label, m.logger)
+}
+
+// add adds a task that is printed with `logger`. The caller must hold progressMu.
+func (m *ProgressManager) add(label string, logger *Logger) *ProgressTask {
+	t := &ProgressTask{manager: m, logger: logger, label: label}
+	m.tasks = append(m.tasks, t)
+	if activeProgress == nil && m.logger.onMessage == nil && terminal.IsTerminal(os.Stdout.Fd()) {
+		activeProgress = m
+		fmt.Print(ansi.HideCursor().String())
+	}
+	m.redraw()
+	return t
+}
+
+// Tasks returns the number of tasks that haven't finished yet.
+func (m *ProgressManager) Tasks() int {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	return len(m.tasks)
+}
+
+// Stop releases the terminal, the bars of unfinished tasks stay on screen as they are.
+// Updates of unfinished tasks are not drawn anymore, but they are still printed when they finish.
+func (m *ProgressManager) Stop() {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	m.release()
+}
+
+// release stops drawing if `m` owns the terminal. The caller must hold progressMu.
+func (m *ProgressManager) release() {
+	if activeProgress != m {
+		return
+	}
+	m.drawn = 0
+	activeProgress = nil
+	fmt.Print(ansi.ShowCursor().String())
+}
+
+// redraw replaces the bars on screen if `m` owns the terminal. The caller must hold progressMu.
+func (m *ProgressManager) redraw() {
+	if activeProgress != m {
+		return
+	}
+	m.clear()
+	m.draw()
+}
+
+// clear removes the bars from the screen, leaving the cursor where the first bar was.
+// The caller must hold progressMu.
+func (m *ProgressManager) clear() {
+	if m.drawn > 0 {
+		fmt.Print(ansi.CursorUp(m.drawn).String() + "\r" + ansi.ClearScreenFromCursor().String())
+	}
+	m.drawn = 0
+}
+
+// draw prints the bars below the cursor. Lines are truncated to the width of the terminal
+// and the number of bars is limited by its height, so that the block can be cleared again.
+// The caller must hold progressMu.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.ProgressBarWidth`
+func (m *ProgressManager) draw() {
+	tasks := m.tasks
+	more := 0
+	if h := terminal.Height(); h > 1 && len(tasks) > h-1 {
+		limit := max(h-2, 0)
+		more = len(tasks) - limit
+		tasks = tasks[:limit]
+	}
+	lines := []string{}
+	for _, t := range tasks {
+		lines = append(lines, t.logger.compose('p', t.line(), false))
+	}
+	if more > 0 {
+		lines = append(lines, m.logger.compose('p', colorizers.IntAmount(more, "more task", "more tasks"), false))
+	}
+	width := terminal.Width()
+	var sb strings.Builder
+	for _, ln := range lines {
+		if width > 0 {
+			ln = utils.Truncate(ln, width-1, "")
+		}
+		if config.LoggerConfig.ColorsDisabled {
+			ln = utils.StripANSI(ln)
+		}
+		sb.WriteString(ln + ansi.ClearToEOL().String() + "\n")
+	}
+	fmt.Print(sb.String())
+	m.drawn = len(lines)
+}
+
+// line returns the progress bar, label (if any) and message of the task.
+func (t *ProgressTask) line() string {
+	if t.custom {
+		return t.message
+	}
+	line := ProgressBar(t.progress, config.LoggerConfig.ProgressBarWidth)
+	if t.label != "" {
+		line += " " + highlightTitle(t.label)
+	}
+	if t.message != "" {
+		line += " " + t.message
+	}
+	return line
+}
+
+// Update sets the progress (0 to 1) and the message shown after the label of the task,
+// formatted like fmt.Sprintf. Updates of finished tasks are ignored.
+func (t *ProgressTask) Update(progress float64, format string, a ...any) {
+	t.update(progress, false, fmt.Sprintf(format, a...))
+}
+
+// update sets the progress and message of the task, if `custom` is true, `msg` replaces the whole line.
+func (t *ProgressTask) update(progress float64, custom bool, msg string) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	if t.done {
+		return
+	}
+	t.progress = max(min(progress, 1), 0)
+	t.custom = custom
+	t.message = msg
+	t.manager.redraw()
+}
+
+// finish removes the task from its manager and prints its final line with the given indicator,
+// the remaining bars are redrawn below it. If `custom` is true, `msg` is the whole line.
+func (t *ProgressTask) finish(indicator rune, progress float64, custom bool, msg string) {
+	progressMu.Lock()
+	if t.done {
+		progressMu.Unlock()
+		return
+	}
+	t.done = true
+	t.progress = max(min(progress, 1), 0)
+	t.custom = custom
+	t.message = msg
+	m := t.manager
+	for i, other := range m.tasks {
+		if other == t {
+			m.tasks = append(m.tasks[:i], m.tasks[i+1:]...)
+			break
+		}
+	}
+	if len(m.tasks) == 0 && activeProgress == m {
+		m.clear()
+		m.release()
+	}
+	progressMu.Unlock()
+
+	t.logger.write(indicator, "%s", t.line())
+}
+
+// managedProgress returns the task that shows Logger.Progress of `l` while a progress manager
+// owns the terminal. If there is none and `create` is true, a task without label is added
+// to the active manager, unless `l` writes to a message handler. Otherwise nil is returned.
+func (l *Logger) managedProgress(create bool) *ProgressTask {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	if t := l.progress; t != nil && !t.done && t.manager == activeProgress {
+		return t
+	}
+	l.progress = nil
+	if !create || activeProgress == nil || l.onMessage != nil {
+		return nil
+	}
+	l.progress = activeProgress.add("", l)
+	return l.progress
+}
+
+// Success finishes the task like Logger.ProgressSuccess: the bar is removed from the block
+// and printed as success message with the given progress and message.
+func (t *ProgressTask) Success(progress float64, format string, a ...any) {
+	t.finish('✓', progress, false, fmt.Sprintf(format, a...))
+}
+
+// Error finishes the task like Logger.ProgressError: the bar is removed from the block
+// and printed as error message with the given progress and message.
+func (t *ProgressTask) Error(progress float64, format string, a ...any) {
+	t.finish('x', progress, false, fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// captureStdout returns everything `fn` prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+	defer func() {
+		os.Stdout = orig
+	}()
+	fn()
+	w.Close()
+	return <-out
+}
+
+// activate makes `m` own the terminal, which Add only does if stdout is a terminal.
+func activate(t *testing.T, m *ProgressManager) {
+	progressMu.Lock()
+	activeProgress = m
+	progressMu.Unlock()
+	t.Cleanup(func() {
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		if activeProgress == m {
+			activeProgress = nil
+		}
+	})
+}
+
+func TestLoggerProgressWithManager(t *testing.T) {
+	withConfig(t, func(c *config.Config) {
+		c.ShowDateTime = false
+		c.ShowRuntimeHumanReadable = false
+		c.ShowRuntimeSeconds = false
+		c.ShowRuntimeMilliseconds = false
+		c.ShowSubsystem = false
+		c.ColorsDisabled = false
+	})
+	l := NewLogger("test", 1, false, nil)
+	m := NewProgressManager(l)
+
+	out := captureStdout(t, func() {
+		m.Add("download")
+		activate(t, m)
+
+		l.Progress(0.5, "half")
+		l.Progress(0.75, "%s", "more")
+		if got := m.Tasks(); got != 2 {
+			t.Errorf("Tasks() = %d, want 2 (Logger.Progress is shown as one task)", got)
+		}
+		if task := l.managedProgress(false); task == nil || task.progress != 0.75 || task.message != "more" {
+			t.Errorf("Logger.Progress task = %+v, want progress 0.75 and message %q", task, "more")
+		}
+
+		l.ProgressSuccess(1, "done")
+		if got := m.Tasks(); got != 1 {
+			t.Errorf("Tasks() after ProgressSuccess = %d, want 1", got)
+		}
+		if task := l.managedProgress(false); task != nil {
+			t.Errorf("Logger.Progress task after ProgressSuccess = %+v, want nil", task)
+		}
+	})
+
+	if strings.Contains(out, ansi.StoreCursor().String()) {
+		t.Errorf("output %q stores the cursor, Logger.Progress wasn't drawn by the manager", out)
+	}
+	if !strings.Contains(utils.StripANSI(out), "half") || !strings.Contains(utils.StripANSI(out), "done") {
+		t.Errorf("output %q doesn't contain the progress messages", utils.StripANSI(out))
+	}
+}
+
+func TestProgressTrackerWithManager(t *testing.T) {
+	withConfig(t, func(c *config.Config) {
+		c.ShowDateTime = false
+		c.ShowRuntimeHumanReadable = false
+		c.ShowRuntimeSeconds = false
+		c.ShowRuntimeMilliseconds = false
+		c.ShowSubsystem = false
+		c.ColorsDisabled = false
+	})
+	l := NewLogger("test", 1, false, nil)
+	m := NewProgressManager(l)
+	tracker := NewProgressTracker(4).SetLayout("{bar} {count}").Set(2)
+
+	out := captureStdout(t, func() {
+		m.Add("download")
+		activate(t, m)
+
+		tracker.Print(l, "copying")
+		if got := m.Tasks(); got != 2 {
+			t.Errorf("Tasks() = %d, want 2 (the tracker is shown as one task)", got)
+		}
+		task := l.managedProgress(false)
+		if task == nil || task.progress != 0.5 {
+			t.Fatalf("tracker task = %+v, want progress 0.5", task)
+		}
+		if got, want := task.line(), tracker.line("copying"); got != want {
+			t.Errorf("tracker task line = %q, want %q (no second bar)", got, want)
+		}
+
+		tracker.Set(4).PrintSuccess(l, "copied")
+		if got := m.Tasks(); got != 1 {
+			t.Errorf("Tasks() after PrintSuccess = %d, want 1", got)
+		}
+		if task := l.managedProgress(false); task != nil {
+			t.Errorf("tracker task after PrintSuccess = %+v, want nil", task)
+		}
+	})
+
+	if strings.Contains(out, ansi.StoreCursor().String()) {
+		t.Errorf("output %q stores the cursor, the tracker wasn't drawn by the manager", out)
+	}
+	if !strings.Contains(utils.StripANSI(out), "2/4 items copying") || !strings.Contains(utils.StripANSI(out), "4/4 items copied") {
+		t.Errorf("output %q doesn't contain the tracker lines", utils.StripANSI(out))
+	}
+}
+
+func TestLoggerProgressWithoutManager(t *testing.T) {
+	var msgs []string
+	l := NewLogger("test", 1, false, func(s string) { msgs = append(msgs, s) })
+	l.Progress(0.5, "half")
+	if len(msgs) != 1 || !strings.Contains(msgs[0], ansi.StoreCursor().String()) {
+		t.Errorf("Progress() = %q, want a line that replaces itself", msgs)
+	}
+	if task := l.managedProgress(false); task != nil {
+		t.Errorf("Logger.Progress task = %+v, want nil", task)
+	}
+}
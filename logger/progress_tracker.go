@@ -0,0 +1,317 @@
+package logger
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/colorizers"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+const (
+	PROGRESS_SMOOTHING       = 0.3                    // default weight of the latest rate sample, see ProgressTracker.SetSmoothing
+	PROGRESS_SAMPLE_INTERVAL = 100 * time.Millisecond // minimum time between rate samples
+)
+
+// ProgressTracker keeps track of the progress of a task with a known total (e.g. bytes to download)
+// and renders it with a progress bar, the counts, the throughput, the elapsed time and the ETA.
+// The rate is an exponential moving average, so that the ETA doesn't jump with every update.
+//
+// The layout is a template with the following placeholders:
+//
+//   - `{bar}`: progress bar and percentage (see ProgressBar)
+//   - `{count}`: current and total count, e.g. "12/100 files" or "1.5 MiB / 10 MiB"
+//   - `{current}`, `{total}`: current or total count
+//   - `{rate}`: smoothed rate per second
+//   - `{elapsed}`: time since the tracker has been created
+//   - `{eta}`: estimated time until the total is reached
+//
+// All methods are safe for concurrent use.
+type ProgressTracker struct {
+	mu        sync.Mutex
+	total     int64
+	current   int64
+	start     time.Time
+	sampled   time.Time // time of the last rate sample
+	sampledAt int64     // count at the last rate sample
+	rate      float64
+	hasRate   bool
+	smoothing float64
+	bytes     bool
+	singular  string
+	plural    string
+	layout    string
+}
+
+// NewProgressTracker creates a tracker for `total` items, use SetBytes or SetUnit to change what is counted.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.ProgressLayout`
+func NewProgressTracker(total int64) *ProgressTracker {
+	now := time.Now()
+	return &ProgressTracker{
+		total:     max(total, 0),
+		start:     now,
+		sampled:   now,
+		smoothing: PROGRESS_SMOOTHING,
+		singular:  "item",
+		plural:    "items",
+		layout:    config.LoggerConfig.ProgressLayout,
+	}
+}
+
+// SetBytes makes the tracker count bytes, counts and rates are shown with IEC-prefixes (e.g. "1.5 MiB/s").
+func (t *ProgressTracker) SetBytes() *ProgressTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bytes = true
+	return t
+}
+
+// SetUnit sets the unit of counted items (default: "item" and "items").
+func (t *ProgressTracker) SetUnit(singular, plural string) *ProgressTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bytes = false
+	t.singular, t.plural = singular, plural
+	return t
+}
+
+// SetLayout sets the template used to render the tracker, see ProgressTracker for the placeholders.
+func (t *ProgressTracker) SetLayout(layout string) *ProgressTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.layout = layout
+	return t
+}
+
+// SetSmoothing sets the weight (0 < `smoothing` <= 1) of the latest rate sample,
+// lower values produce steadier but slower reacting rates. The default is PROGRESS_SMOOTHING.
+func (t *ProgressTracker) SetSmoothing(smoothing float64) *ProgressTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if smoothing > 0 && smoothing <= 1 {
+		t.smoothing = smoothing
+	}
+	return t
+}
+
+// SetTotal changes the total count, e.g. when it's only known after the task has started.
+func (t *ProgressTracker) SetTotal(total int64) *ProgressTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total = max(total, 0)
+	return t
+}
+
+// Add increases the current count by `n`.
+func (t *ProgressTracker) Add(n int64) *ProgressTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update(t.current + n)
+	return t
+}
+
+// Set sets the current count.
+func (t *ProgressTracker) Set(current int64) *ProgressTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update(current)
+	return t
+}
+
+// update sets the current count and takes a rate sample if enough time has passed since the last one.
+// The caller must hold t.mu.
+func (t *ProgressTracker) update(current int64) {
+	t.current = max(current, 0)
+	now := time.Now()
+	dt := now.Sub(t.sampled)
+	if dt < PROGRESS_SAMPLE_INTERVAL {
+		return
+	}
+	sample := float64(t.current-t.sampledAt) / dt.Seconds()
+	if t.hasRate {
+		t.rate = t.smoothedRate(sample, dt)
+	} else {
+		t.rate = sample
+		t.hasRate = true
+	}
+	t.sampled, t.sampledAt = now, t.current
+}
+
+// smoothedRate returns the rate after adding `sample`, which has been measured over `dt`.
+// The weight of the sample grows with `dt` as if a sample had been taken every PROGRESS_SAMPLE_INTERVAL,
+// so long pauses between updates count as much as many short ones. The caller must hold t.mu.
+func (t *ProgressTracker) smoothedRate(sample float64, dt time.Duration) float64 {
+	w := 1 - math.Pow(1-t.smoothing, dt.Seconds()/PROGRESS_SAMPLE_INTERVAL.Seconds())
+	return w*sample + (1-w)*t.rate
+}
+
+// Current returns the current count.
+func (t *ProgressTracker) Current() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.current
+}
+
+// Total returns the total count.
+func (t *ProgressTracker) Total() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
+
+// Progress returns the progress from 0 to 1, 0 if the total is unknown.
+func (t *ProgressTracker) Progress() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.progress()
+}
+
+func (t *ProgressTracker) progress() float64 {
+	if t.total <= 0 {
+		return 0
+	}
+	return min(float64(t.current)/float64(t.total), 1)
+}
+
+// Rate returns the smoothed rate per second. Until the first sample has been taken,
+// the average rate since the tracker has been created is returned.
+// The time since the last sample is taken into account, so the rate of a stalled task decays towards 0.
+func (t *ProgressTracker) Rate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.currentRate()
+}
+
+func (t *ProgressTracker) currentRate() float64 {
+	if t.hasRate {
+		rate := t.rate
+		if dt := time.Since(t.sampled); dt >= PROGRESS_SAMPLE_INTERVAL { // no update since the last sample
+			rate = t.smoothedRate(float64(t.current-t.sampledAt)/dt.Seconds(), dt)
+		}
+		return max(rate, 0)
+	}
+	if elapsed := time.Since(t.start).Seconds(); elapsed > 0 {
+		return float64(t.current) / elapsed
+	}
+	return 0
+}
+
+// Elapsed returns the time since the tracker has been created.
+func (t *ProgressTracker) Elapsed() time.Duration {
+	return time.Since(t.start)
+}
+
+// ETA returns the estimated time until the total is reached or -1 if it can't be estimated
+// (unknown total or no progress yet).
+func (t *ProgressTracker) ETA() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.eta()
+}
+
+func (t *ProgressTracker) eta() time.Duration {
+	if t.total <= 0 {
+		return -1
+	}
+	if t.current >= t.total {
+		return 0
+	}
+	rate := t.currentRate()
+	if rate <= 0 {
+		return -1
+	}
+	return time.Duration(float64(t.total-t.current) / rate * float64(time.Second))
+}
+
+// count formats `n` according to the unit of the tracker.
+func (t *ProgressTracker) count(n int64) string {
+	if t.bytes {
+		return colorizers.HumanReadableBytesIEC(n)
+	}
+	return colorizers.IntAmount(n, t.singular, t.plural)
+}
+
+// String renders the tracker according to its layout.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.ProgressBarWidth`
+//   - `LoggerConfig.AutoFloatPrecision`
+//   - `LoggerConfig.ColorProgressUnknown`
+func (t *ProgressTracker) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	unknown := ansi.Wrap("?", config.LoggerConfig.ColorProgressUnknown).String()
+	count := t.count(t.current) + " / " + t.count(t.total)
+	if !t.bytes {
+		count = colorizers.Int(t.current) + "/" + t.count(t.total)
+	}
+	rate := colorizers.Float(t.currentRate(), config.LoggerConfig.AutoFloatPrecision) + " " + t.plural + "/s"
+	if t.bytes {
+		rate = colorizers.HumanReadableRateBytesIEC(t.currentRate(), "s")
+	}
+	eta := unknown
+	if d := t.eta(); d >= 0 {
+		eta = colorizers.DurationShort(d.Seconds(), utils.DURATION_SCALE_AVERAGE)
+	}
+	total := t.count(t.total)
+	if t.total <= 0 {
+		count, total = t.count(t.current), unknown
+	}
+
+	return strings.NewReplacer(
+		"{bar}", ProgressBar(t.progress(), config.LoggerConfig.ProgressBarWidth),
+		"{count}", count,
+		"{current}", t.count(t.current),
+		"{total}", total,
+		"{rate}", rate,
+		"{elapsed}", colorizers.DurationShort(time.Since(t.start).Seconds(), utils.DURATION_SCALE_AVERAGE),
+		"{eta}", eta,
+	).Replace(t.layout)
+}
+
+// Print draws the tracker like Logger.Progress, followed by the given format and arguments (if any).
+// While a progress manager owns the terminal, the tracker is shown as a task of that manager.
+func (t *ProgressTracker) Print(logger *Logger, format string, a ...any) {
+	if task := logger.managedProgress(true); task != nil {
+		task.update(t.Progress(), true, t.line(format, a...))
+		return
+	}
+	logger.write('p', "%s", t.line(format, a...))
+}
+
+// PrintSuccess prints the tracker like Logger.ProgressSuccess, followed by the given format and arguments (if any).
+func (t *ProgressTracker) PrintSuccess(logger *Logger, format string, a ...any) {
+	if task := logger.managedProgress(false); task != nil {
+		task.finish('✓', t.Progress(), true, t.line(format, a...))
+		return
+	}
+	logger.Success("%s%s", ansi.ClearToEOL().String(), t.line(format, a...))
+}
+
+// PrintError prints the tracker like Logger.ProgressError, followed by the given format and arguments (if any).
+func (t *ProgressTracker) PrintError(logger *Logger, format string, a ...any) {
+	if task := logger.managedProgress(false); task != nil {
+		task.finish('x', t.Progress(), true, t.line(format, a...))
+		return
+	}
+	logger.Error("%s%s", ansi.ClearToEOL().String(), t.line(format, a...))
+}
+
+// line returns the rendered tracker followed by the formatted message.
+func (t *ProgressTracker) line(format string, a ...any) string {
+	line := t.String()
+	if msg := fmt.Sprintf(format, a...); msg != "" {
+		line += " " + msg
+	}
+	return line
+}
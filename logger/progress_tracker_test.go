@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// newSampledTracker returns a tracker at `current` of `total` whose last rate sample
+// (`rate` per second) has been taken `ago`.
+func newSampledTracker(total, current int64, rate float64, ago time.Duration) *ProgressTracker {
+	t := NewProgressTracker(total)
+	t.current, t.sampledAt = current, current
+	t.rate, t.hasRate = rate, true
+	t.sampled = time.Now().Add(-ago)
+	t.start = time.Now().Add(-5 * time.Second)
+	return t
+}
+
+func TestProgressTrackerRate(t *testing.T) {
+	tr := NewProgressTracker(1000)
+	tr.sampled = time.Now().Add(-time.Second)
+	tr.Set(100)
+	if got := tr.Rate(); math.Abs(got-100) > 5 {
+		t.Errorf("Rate() after the first sample = %f, want ~100", got)
+	}
+
+	// with a smoothing of 0.5 and one interval since the last sample, both samples weigh the same
+	tr = newSampledTracker(1000, 100, 100, PROGRESS_SAMPLE_INTERVAL).SetSmoothing(0.5)
+	tr.Set(100 + 30) // 300/s
+	if got := tr.Rate(); math.Abs(got-200) > 10 {
+		t.Errorf("Rate() after the second sample = %f, want ~200", got)
+	}
+
+	// updates within the sample interval don't change the rate
+	tr = newSampledTracker(1000, 100, 100, 0)
+	tr.Add(500)
+	if got := tr.Rate(); got != 100 {
+		t.Errorf("Rate() within the sample interval = %f, want 100", got)
+	}
+}
+
+func TestProgressTrackerRateDecays(t *testing.T) {
+	tests := []struct {
+		name string
+		ago  time.Duration
+		max  float64
+	}{
+		{name: "one interval", ago: PROGRESS_SAMPLE_INTERVAL, max: 100 * (1 - PROGRESS_SMOOTHING) * 1.05},
+		{name: "ten intervals", ago: 10 * PROGRESS_SAMPLE_INTERVAL, max: 100 * math.Pow(1-PROGRESS_SMOOTHING, 10) * 1.05},
+		{name: "a minute", ago: time.Minute, max: 0.001},
+	}
+
+	for _, tt := range tests {
+		tr := newSampledTracker(1000, 100, 100, tt.ago)
+		if got := tr.Rate(); got > tt.max {
+			t.Errorf("%s: Rate() of a stalled task = %f, want <= %f", tt.name, got, tt.max)
+		}
+	}
+}
+
+func TestProgressTrackerETA(t *testing.T) {
+	tests := []struct {
+		name     string
+		tracker  *ProgressTracker
+		expected time.Duration
+	}{
+		{name: "half done", tracker: newSampledTracker(100, 50, 10, 0), expected: 5 * time.Second},
+		{name: "done", tracker: newSampledTracker(100, 100, 0, 0), expected: 0},
+		{name: "unknown total", tracker: newSampledTracker(0, 50, 10, 0), expected: -1},
+		{name: "no progress", tracker: newSampledTracker(100, 0, 0, 0), expected: -1},
+	}
+
+	for _, tt := range tests {
+		if got := tt.tracker.ETA(); got != tt.expected {
+			t.Errorf("%s: ETA() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestProgressTrackerLayout(t *testing.T) {
+	withConfig(t, func(c *config.Config) {
+		c.AutoFloatPrecision = 2
+		c.ProgressBarWidth = 4
+	})
+	layout := "{bar}|{count}|{current}|{total}|{rate}|{eta}"
+
+	tests := []struct {
+		name     string
+		tracker  *ProgressTracker
+		expected string
+	}{
+		{
+			name:     "items",
+			tracker:  newSampledTracker(100, 50, 10, 0).SetUnit("file", "files").SetLayout(layout),
+			expected: "■■▫▫ 50.00%|50/100 files|50 files|100 files|10.00 files/s|5.00sec",
+		},
+		{
+			name:     "bytes",
+			tracker:  newSampledTracker(100, 50, 10, 0).SetBytes().SetLayout(layout),
+			expected: "■■▫▫ 50.00%|50.00   B / 100.00   B|50.00   B|100.00   B|10.00   B/s|5.00sec",
+		},
+		{
+			name:     "unknown total",
+			tracker:  newSampledTracker(0, 50, 10, 0).SetLayout(layout),
+			expected: "▫▫▫▫ 0.00%|50 items|50 items|?|10.00 items/s|?",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := utils.StripANSI(tt.tracker.String()); got != tt.expected {
+			t.Errorf("%s: String() = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestProgressTrackerUnknownColor(t *testing.T) {
+	withConfig(t, func(c *config.Config) {
+		c.ColorProgressUnknown = 123
+	})
+	got := newSampledTracker(0, 0, 0, 0).SetLayout("{eta}").String()
+	if expected := ansi.Wrap("?", 123).String(); got != expected {
+		t.Errorf("String() = %q, want %q", got, expected)
+	}
+}
@@ -0,0 +1,316 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/utils"
+)
+
+const (
+	STREAM_LEVEL_DEBUG = iota
+	STREAM_LEVEL_DEFAULT
+	STREAM_LEVEL_INFO
+	STREAM_LEVEL_WARNING
+	STREAM_LEVEL_ERROR
+)
+
+var streamLevelNames = map[string]int{
+	"debug":   STREAM_LEVEL_DEBUG,
+	"trace":   STREAM_LEVEL_DEBUG,
+	"default": STREAM_LEVEL_DEFAULT,
+	"info":    STREAM_LEVEL_INFO,
+	"ok":      STREAM_LEVEL_INFO,
+	"success": STREAM_LEVEL_INFO,
+	"warning": STREAM_LEVEL_WARNING,
+	"warn":    STREAM_LEVEL_WARNING,
+	"notok":   STREAM_LEVEL_WARNING,
+	"error":   STREAM_LEVEL_ERROR,
+}
+
+// streamLevel maps a message indicator to the level used for filtering streams.
+// Unknown (custom) indicators are treated as default level.
+func streamLevel(indicator rune) int {
+	switch indicator {
+	case 'd', 't':
+		return STREAM_LEVEL_DEBUG
+	case 'i', '+', '✓':
+		return STREAM_LEVEL_INFO
+	case '!', '-':
+		return STREAM_LEVEL_WARNING
+	case 'x':
+		return STREAM_LEVEL_ERROR
+	}
+	return STREAM_LEVEL_DEFAULT
+}
+
+type streamMessage struct {
+	id    string
+	level int
+	msg   string
+}
+
+type streamSubscriber struct {
+	ch      chan *streamMessage
+	ids     map[string]bool // empty means all loggers
+	level   int
+	dropped atomic.Uint64
+}
+
+func (s *streamSubscriber) accepts(m *streamMessage) bool {
+	if m.level < s.level {
+		return false
+	}
+	if len(s.ids) > 0 && !s.ids[m.id] {
+		return false
+	}
+	return true
+}
+
+type streamHub struct {
+	lock        *sync.RWMutex
+	subscribers map[*streamSubscriber]struct{}
+	count       atomic.Int32
+}
+
+func (h *streamHub) subscribe(s *streamSubscriber) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.subscribers[s] = struct{}{}
+	h.count.Add(1)
+}
+
+func (h *streamHub) unsubscribe(s *streamSubscriber) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, ok := h.subscribers[s]; ok {
+		delete(h.subscribers, s)
+		h.count.Add(-1)
+	}
+}
+
+// publish hands the message to all interested subscribers.
+// It never blocks: subscribers that can't keep up lose messages,
+// which is reported to them once they catch up again.
+func (h *streamHub) publish(id string, indicator rune, msg string) {
+	if h.count.Load() == 0 {
+		return // nobody is listening, keep the logging path cheap
+	}
+	m := &streamMessage{
+		id:    id,
+		level: streamLevel(indicator),
+		msg:   strings.TrimSuffix(msg, "\n"),
+	}
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	for s := range h.subscribers {
+		if !s.accepts(m) {
+			continue
+		}
+		select {
+		case s.ch <- m:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
+
+var streams = &streamHub{
+	lock:        &sync.RWMutex{},
+	subscribers: map[*streamSubscriber]struct{}{},
+}
+
+// StreamHandler is an http.Handler that streams log messages of all loggers live to its clients.
+//
+// Browsers get a page that follows the log using Server-Sent Events and renders colors as HTML,
+// clients requesting `text/event-stream` get the SSE feed directly and all other clients
+// (e.g. `curl`) get the raw messages as a chunked text stream.
+//
+// Supported query parameters:
+//
+//   - `id`: only stream messages of the logger(s) with this ID, can be repeated or comma-separated
+//   - `level`: minimum level to stream (`debug`, `default`, `info`, `warning` or `error`)
+//   - `plain`: if `true`, ANSI escapes are stripped from the raw text stream
+type StreamHandler struct {
+	BufferSize int           // number of messages buffered per client before messages are dropped
+	KeepAlive  time.Duration // interval of keep-alive comments on SSE connections, 0 disables them
+}
+
+func (sh *StreamHandler) subscriber(r *http.Request) (*streamSubscriber, error) {
+	q := r.URL.Query()
+	s := &streamSubscriber{
+		ch:    make(chan *streamMessage, max(1, sh.BufferSize)),
+		ids:   map[string]bool{},
+		level: STREAM_LEVEL_DEBUG,
+	}
+	for _, v := range q["id"] {
+		for id := range strings.SplitSeq(v, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				s.ids[id] = true
+			}
+		}
+	}
+	if lvl := strings.ToLower(q.Get("level")); lvl != "" {
+		l, ok := streamLevelNames[lvl]
+		if !ok {
+			return nil, fmt.Errorf("unknown level %q", lvl)
+		}
+		s.level = l
+	}
+	return s, nil
+}
+
+func (sh *StreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	accept := r.Header.Get("Accept")
+	if strings.Contains(accept, "text/html") && !strings.Contains(accept, "text/event-stream") {
+		sh.servePage(w, r)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	s, err := sh.subscriber(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sse := strings.Contains(accept, "text/event-stream")
+	plain, _ := strconv.ParseBool(r.URL.Query().Get("plain"))
+
+	h := w.Header()
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Content-Type-Options", "nosniff")
+	if sse {
+		h.Set("Content-Type", "text/event-stream; charset=utf-8")
+	} else {
+		h.Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	streams.subscribe(s)
+	defer streams.unsubscribe(s)
+
+	var keepAlive <-chan time.Time
+	if sse && sh.KeepAlive > 0 {
+		t := time.NewTicker(sh.KeepAlive)
+		defer t.Stop()
+		keepAlive = t.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case m := <-s.ch:
+			msg := m.msg
+			if n := s.dropped.Swap(0); n > 0 {
+				msg = fmt.Sprintf("... %d message(s) dropped ...\n%s", n, msg)
+			}
+			if sse {
+				err = writeSSE(w, msg)
+			} else {
+				if plain {
+					msg = utils.StripANSI(msg)
+				}
+				_, err = fmt.Fprintln(w, msg)
+			}
+			if err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// sseLines splits `msg` into lines that keep the styles of the lines before them,
+// so a style that is opened on one line and closed on a later one applies to all lines in between.
+func sseLines(msg string) []string {
+	lines := [][]ansi.Span{{}}
+	for _, sp := range ansi.Parse(msg) {
+		for i, text := range strings.Split(sp.Text, "\n") {
+			if i > 0 {
+				lines = append(lines, []ansi.Span{})
+			}
+			if text != "" {
+				lines[len(lines)-1] = append(lines[len(lines)-1], ansi.Span{Text: text, Style: sp.Style})
+			}
+		}
+	}
+	res := make([]string, len(lines))
+	for i, spans := range lines {
+		res[i] = ansi.Render(spans)
+	}
+	return res
+}
+
+func writeSSE(w http.ResponseWriter, msg string) error {
+	var sb strings.Builder
+	sb.WriteString("event: log\n")
+	for _, ln := range sseLines(msg) {
+		sb.WriteString("data: ")
+		sb.WriteString(ansi.ToHTML(ln, nil))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	_, err := fmt.Fprint(w, sb.String())
+	return err
+}
+
+func (sh *StreamHandler) servePage(w http.ResponseWriter, r *http.Request) {
+	path, _ := json.Marshal(r.URL.Path) // also escapes <, > and & for use inside the script tag
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, streamPage, path)
+}
+
+const streamPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>glog</title>
+<style>
+body { margin: 0; background: #000; color: #ccc; }
+pre { margin: 0; padding: 0.5em; font-family: monospace; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<pre id="log"></pre>
+<script>
+const log = document.getElementById("log");
+const src = new EventSource(%s + window.location.search);
+src.addEventListener("log", (e) => {
+	const follow = window.innerHeight + window.scrollY >= document.body.offsetHeight - 2;
+	log.insertAdjacentHTML("beforeend", e.data.replaceAll("\n", "<br>") + "<br>");
+	if (follow) {
+		window.scrollTo(0, document.body.scrollHeight);
+	}
+});
+</script>
+</body>
+</html>
+`
+
+// NewStreamHandler creates a StreamHandler with a buffer of 256 messages per client
+// and keep-alive comments every 15 seconds.
+func NewStreamHandler() *StreamHandler {
+	return &StreamHandler{
+		BufferSize: 256,
+		KeepAlive:  15 * time.Second,
+	}
+}
@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitSubscribers waits until the stream hub has `n` subscribers.
+func waitSubscribers(t *testing.T, n int32) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for streams.count.Load() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("stream hub has %d subscribers, want %d", streams.count.Load(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// readStream connects to `url` and returns the first `n` non-empty lines of the response body.
+func readStream(t *testing.T, url, accept string, n int, publish func()) []string {
+	t.Helper()
+	waitSubscribers(t, 0) // clients of previous requests
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: status %d", url, resp.StatusCode)
+	}
+
+	waitSubscribers(t, 1) // the subscription is registered after the headers have been sent
+	publish()
+
+	lines := make(chan string, 64)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			if sc.Text() != "" {
+				lines <- sc.Text()
+			}
+		}
+	}()
+	res := []string{}
+	for len(res) < n {
+		select {
+		case ln, ok := <-lines:
+			if !ok {
+				t.Fatalf("stream ended after %q", res)
+			}
+			res = append(res, ln)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timeout after %q", res)
+		}
+	}
+	return res
+}
+
+func TestStreamHandlerFilters(t *testing.T) {
+	srv := httptest.NewServer(NewStreamHandler())
+	defer srv.Close()
+
+	discard := func(string) {}
+	a := NewLogger("stream-a", 1, true, discard)
+	b := NewLogger("stream-b", 2, true, discard)
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{
+			query:    "?id=stream-a&level=warning&plain=true",
+			expected: []string{"a warning", "a error"},
+		},
+		{
+			query:    "?id=stream-b,stream-x&plain=true",
+			expected: []string{"b debug", "b info", "b error"},
+		},
+		{
+			query:    "?level=error&plain=true",
+			expected: []string{"a error", "b error"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := readStream(t, srv.URL+tt.query, "", len(tt.expected), func() {
+			a.Debug("a debug")
+			a.Info("a info")
+			a.Warning("a warning")
+			b.Debug("b debug")
+			b.Info("b info")
+			a.Error("a error")
+			b.Error("b error")
+		})
+		for i, ln := range got {
+			if strings.Contains(ln, "\033") {
+				t.Errorf("%s: line %q contains ANSI escapes", tt.query, ln)
+			}
+			if !strings.HasSuffix(ln, tt.expected[i]) {
+				t.Errorf("%s: line %d = %q, want suffix %q", tt.query, i, ln, tt.expected[i])
+			}
+		}
+	}
+}
+
+func TestStreamHandlerSSE(t *testing.T) {
+	srv := httptest.NewServer(NewStreamHandler())
+	defer srv.Close()
+
+	l := NewLogger("stream-sse", 1, false, func(string) {})
+	got := readStream(t, srv.URL+"?id=stream-sse", "text/event-stream", 2, func() {
+		l.Info("<b>")
+	})
+	if got[0] != "event: log" {
+		t.Errorf("first line = %q, want %q", got[0], "event: log")
+	}
+	if !strings.HasPrefix(got[1], "data: ") || !strings.Contains(got[1], "<span") || !strings.HasSuffix(got[1], "&lt;b&gt;") {
+		t.Errorf("data line = %q, want HTML with escaped message", got[1])
+	}
+}
+
+func TestStreamHandlerBadLevel(t *testing.T) {
+	srv := httptest.NewServer(NewStreamHandler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?level=verbose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestStreamHubDropsWithoutBlocking(t *testing.T) {
+	s := &streamSubscriber{ch: make(chan *streamMessage, 2), ids: map[string]bool{}}
+	streams.subscribe(s)
+	defer streams.unsubscribe(s)
+
+	done := make(chan struct{})
+	go func() {
+		for range 5 {
+			streams.publish("stream-drop", 'i', "msg\n")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("publish blocked on a full subscriber")
+	}
+	if got := s.dropped.Load(); got != 3 {
+		t.Errorf("dropped = %d, want 3", got)
+	}
+	if m := <-s.ch; m.msg != "msg" || m.level != STREAM_LEVEL_INFO {
+		t.Errorf("message = %+v, want trimmed info message", m)
+	}
+}
+
+func TestSSELinesKeepStyles(t *testing.T) {
+	tests := []struct {
+		msg      string
+		expected []string
+	}{
+		{
+			msg:      "plain\nlines",
+			expected: []string{"plain", "lines"},
+		},
+		{
+			msg:      "\033[38;5;196mred\nstill red\033[0m\nplain",
+			expected: []string{"\033[38;5;196mred\033[0m", "\033[38;5;196mstill red\033[0m", "plain"},
+		},
+		{
+			msg:      "a \033[1mbold\n\nbold\033[0m b",
+			expected: []string{"a \033[1mbold\033[0m", "", "\033[1mbold\033[0m b"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := sseLines(tt.msg)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("sseLines(%q) = %q, want %q", tt.msg, got, tt.expected)
+		}
+	}
+}
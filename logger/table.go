@@ -2,11 +2,11 @@ package logger
 
 import (
 	"encoding/json"
-	"fmt"
 	"strings"
 
 	"github.com/toxyl/glog/colorizers"
 	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/terminal"
 	"github.com/toxyl/glog/utils"
 	"github.com/toxyl/math"
 	"gopkg.in/yaml.v3"
@@ -18,6 +18,23 @@ const (
 	PAD_RIGHT
 )
 
+const (
+	OVERFLOW_WRAP = iota
+	OVERFLOW_TRUNCATE
+)
+
+const (
+	VALIGN_TOP = iota
+	VALIGN_MIDDLE
+	VALIGN_BOTTOM
+)
+
+const (
+	TABLE_WIDTH_AUTO    = -1 // use the width of the terminal
+	TABLE_ELLIPSIS      = "…"
+	TABLE_MISSING_VALUE = "N/A" // shown in columns that have less rows than the table
+)
+
 type TableColumn struct {
 	Name        string
 	values      []string
@@ -26,12 +43,34 @@ type TableColumn struct {
 	padDir      int
 	padChar     rune
 	fnHighlight func(a ...any) string
+
+	minWidth       int
+	maxWidth       int
+	shrinkPriority int
+	overflow       int
+	valign         int
+	aggregate      TableAggregate
+	rules          []tableRule
+	heatmap        bool
+	heatmapFrom    int
+	heatmapTo      int
+	collapse       bool
 }
 
 func (t *TableColumn) Reset() {
 	t.valuesRaw = []any{}
 	t.values = []string{}
-	t.maxLen = len(t.Name)
+	t.maxLen = linesWidth(t.Name)
+}
+
+// keepLastRow removes all values except for the last one but keeps the width of the column.
+func (t *TableColumn) keepLastRow() {
+	if n := len(t.values); n > 0 {
+		t.values = append(t.values[:0], t.values[n-1])
+	}
+	if n := len(t.valuesRaw); n > 0 {
+		t.valuesRaw = append(t.valuesRaw[:0], t.valuesRaw[n-1])
+	}
 }
 
 func (t *TableColumn) Values() []any {
@@ -40,42 +79,109 @@ func (t *TableColumn) Values() []any {
 
 func (t *TableColumn) Push(value ...any) *TableColumn {
 	for _, v := range value {
-		vs := t.fnHighlight(v)
-		vl := len(utils.StripANSI(vs))
+		vs := t.highlightValue(v)
 		t.values = append(t.values, vs)
 		t.valuesRaw = append(t.valuesRaw, v)
-		t.maxLen = math.Max(t.maxLen, vl)
+		if _, ok := v.(*TableSpan); !ok && !isSeparatorValue(v) { // spans are fitted into the columns they cover, separators fill any width
+			t.maxLen = math.Max(t.maxLen, linesWidth(vs))
+		}
 	}
 	return t
 }
 
-func (t *TableColumn) padValues() *TableColumn {
-	for i := range t.values {
-		vs := t.values[i]
-		switch t.padDir {
-		case PAD_LEFT:
-			vs = utils.PadLeft(vs, t.maxLen, t.padChar)
-		case PAD_CENTER:
-			vs = utils.PadCenter(vs, t.maxLen, t.padChar)
-		case PAD_RIGHT:
-			vs = utils.PadRight(vs, t.maxLen, t.padChar)
-		}
-		t.values[i] = vs
-	}
+// SetMinWidth sets the minimum width of the column, it won't be shrunk below that
+// to fit the table's max width. If it's 0 (default), the column is shrunk to the width
+// of its header first and only shrunk further if that isn't enough.
+func (t *TableColumn) SetMinWidth(width int) *TableColumn {
+	t.minWidth = width
 	return t
 }
 
-func (t *TableColumn) header() string {
-	h := colorizers.Auto(t.Name)
+// SetMaxWidth sets the maximum width of the column, longer values overflow (see SetOverflow).
+// Use 0 for no limit.
+func (t *TableColumn) SetMaxWidth(width int) *TableColumn {
+	t.maxWidth = width
+	return t
+}
+
+// SetShrinkPriority sets the priority of the column when the table has to be shrunk
+// to fit its max width. Columns with a higher priority are shrunk first,
+// columns with the same priority are shrunk widest first.
+func (t *TableColumn) SetShrinkPriority(priority int) *TableColumn {
+	t.shrinkPriority = priority
+	return t
+}
+
+// SetOverflow determines what happens to values that are wider than the column:
+// they are either wrapped over multiple lines (OVERFLOW_WRAP) or truncated (OVERFLOW_TRUNCATE).
+func (t *TableColumn) SetOverflow(mode int) *TableColumn {
+	t.overflow = mode
+	return t
+}
+
+// SetVerticalAlign determines where values are placed if a row is higher than the value
+// (because another value of the row spans multiple lines): VALIGN_TOP, VALIGN_MIDDLE or VALIGN_BOTTOM.
+func (t *TableColumn) SetVerticalAlign(valign int) *TableColumn {
+	t.valign = valign
+	return t
+}
+
+// linesWidth returns the width of the widest line of `str`.
+func linesWidth(str string) int {
+	w := 0
+	for ln := range strings.SplitSeq(str, "\n") {
+		w = max(w, utils.StringWidth(ln))
+	}
+	return w
+}
+
+func (t *TableColumn) pad(str string, width int) string {
 	switch t.padDir {
 	case PAD_LEFT:
-		h = utils.PadLeft(h, t.maxLen, t.padChar)
+		str = utils.PadLeft(str, width, t.padChar)
 	case PAD_CENTER:
-		h = utils.PadCenter(h, t.maxLen, t.padChar)
+		str = utils.PadCenter(str, width, t.padChar)
 	case PAD_RIGHT:
-		h = utils.PadRight(h, t.maxLen, t.padChar)
+		str = utils.PadRight(str, width, t.padChar)
+	}
+	return str
+}
+
+// fit turns `str` into padded lines of the given width, wrapping or truncating lines that are too wide.
+func (t *TableColumn) fit(str string, width int) []string {
+	lines := []string{str}
+	if strings.Contains(str, "\n") {
+		lines = utils.SplitLines(str)
+	}
+	res := []string{}
+	for _, ln := range lines {
+		if utils.StringWidth(ln) <= width {
+			res = append(res, t.pad(ln, width))
+			continue
+		}
+		switch t.overflow {
+		case OVERFLOW_TRUNCATE:
+			res = append(res, t.pad(utils.Truncate(ln, width, TABLE_ELLIPSIS), width))
+		default:
+			for _, wl := range utils.Wrap(ln, width) {
+				res = append(res, t.pad(wl, width))
+			}
+		}
+	}
+	return res
+}
+
+// width returns the width of the column before shrinking, `extra` are values shown in the column
+// that aren't part of it (the highlighted footer value, TABLE_MISSING_VALUE).
+func (t *TableColumn) width(extra ...string) int {
+	w := max(t.maxLen, t.minWidth)
+	for _, v := range extra {
+		w = max(w, linesWidth(v))
 	}
-	return h
+	if t.maxWidth > 0 {
+		w = min(w, t.maxWidth)
+	}
+	return w
 }
 
 func NewTableColumnCustom(name string, padDirection int, padChar rune, highlighter func(a ...any) string) *TableColumn {
@@ -86,7 +192,7 @@ func NewTableColumnCustom(name string, padDirection int, padChar rune, highlight
 	return &TableColumn{
 		Name:        name,
 		values:      []string{},
-		maxLen:      len(name),
+		maxLen:      linesWidth(name),
 		padDir:      padDirection,
 		padChar:     padChar,
 		fnHighlight: h,
@@ -125,24 +231,147 @@ func NewTableColumnCenter(name string) *TableColumn {
 }
 
 type Table struct {
-	series []*TableColumn
+	series      []*TableColumn
+	maxWidth    int
+	borderStyle int
+	stripes     int
+	rowRules    []tableRowRule
 }
 
-func (t *Table) RawData() [][]any {
-	// columns might have different row amounts,
-	// let's find the maximum number of rows in a column
+// SetBorderStyle sets the style of the table's borders, header separator and separator rows:
+// BORDER_LIGHT, BORDER_HEAVY, BORDER_DOUBLE, BORDER_ROUNDED, BORDER_ASCII, BORDER_MARKDOWN, BORDER_NONE or BORDER_COMPACT.
+func (t *Table) SetBorderStyle(style int) *Table {
+	t.borderStyle = style
+	return t
+}
+
+// SetMaxWidth sets the maximum width of the table when rendered with Rows or Print.
+// If the table is wider, its columns are shrunk (see TableColumn.SetShrinkPriority)
+// and values that don't fit anymore are wrapped or truncated (see TableColumn.SetOverflow).
+//
+// Use 0 for no limit or TABLE_WIDTH_AUTO to use the width of the terminal.
+func (t *Table) SetMaxWidth(width int) *Table {
+	t.maxWidth = width
+	return t
+}
+
+// availableWidth returns the max width of the table, `reserved` is subtracted
+// from the terminal width when using TABLE_WIDTH_AUTO.
+func (t *Table) availableWidth(reserved int) int {
+	if t.maxWidth != TABLE_WIDTH_AUTO {
+		return t.maxWidth
+	}
+	w := terminal.Width()
+	if w <= 0 {
+		return 0 // no terminal, no limit
+	}
+	return max(w-reserved, 1)
+}
+
+// columnWidths returns the widths of all columns so that the table fits into `maxWidth`,
+// as far as the min widths of the columns allow it. `footer` contains the footer values (if any).
+// Columns with less rows than the table are at least as wide as TABLE_MISSING_VALUE.
+func (t *Table) columnWidths(maxWidth int, footer []string) []int {
+	numRows := t.numRows()
+	widths := make([]int, len(t.series))
+	minWidths := make([]int, len(t.series))
+	for i, col := range t.series {
+		extra := []string{}
+		if footer != nil {
+			extra = append(extra, footer[i])
+		}
+		minWidths[i] = max(col.minWidth, 1)
+		if len(col.valuesRaw) < numRows {
+			extra = append(extra, TABLE_MISSING_VALUE)
+			minWidths[i] = max(minWidths[i], utils.StringWidth(TABLE_MISSING_VALUE))
+		}
+		widths[i] = col.width(extra...)
+	}
+	border := getBorderSet(t.borderStyle)
+	t.growSpans(border, widths)
+	total := border.width(widths)
+	if maxWidth <= 0 {
+		return widths
+	}
+	// columns without min width are first shrunk to the width of their header,
+	// only if that's not enough they are shrunk further
+	floors := []func(i int) int{
+		func(i int) int {
+			if t.series[i].minWidth > 0 {
+				return minWidths[i]
+			}
+			return max(utils.StringWidth(t.series[i].Name), minWidths[i])
+		},
+		func(i int) int { return minWidths[i] },
+	}
+	for _, floor := range floors {
+		for total > maxWidth {
+			shrink := -1
+			for i, col := range t.series {
+				if widths[i] <= floor(i) {
+					continue
+				}
+				if shrink < 0 {
+					shrink = i
+					continue
+				}
+				best := t.series[shrink]
+				if col.shrinkPriority > best.shrinkPriority || (col.shrinkPriority == best.shrinkPriority && widths[i] > widths[shrink]) {
+					shrink = i
+				}
+			}
+			if shrink < 0 {
+				break // nothing left to shrink
+			}
+			widths[shrink]--
+			total--
+		}
+	}
+	return widths
+}
+
+// numRows returns the number of rows of the longest column.
+func (t *Table) numRows() int {
 	numRows := 0
 	for _, col := range t.series {
 		numRows = math.Max(numRows, len(col.valuesRaw))
 	}
+	return numRows
+}
 
-	// now we pad every column with
-	// less rows than the max with nil
+// normalize pads every column with less rows than the longest column with nil.
+func (t *Table) normalize() {
+	numRows := t.numRows()
 	for _, col := range t.series {
 		for len(col.valuesRaw) < numRows {
 			col.Push(nil)
 		}
 	}
+}
+
+// isSeparatorValue returns true if `v` is a separator ("---"), which is only used for visual representation.
+func isSeparatorValue(v any) bool {
+	str, ok := v.(string)
+	return ok && strings.TrimSpace(utils.StripANSI(str)) == "---"
+}
+
+// isSeparatorRow returns true if any cell of the row is a separator.
+func (t *Table) isSeparatorRow(row int) bool {
+	for _, col := range t.series {
+		if row < len(col.valuesRaw) && isSeparatorValue(col.valuesRaw[row]) {
+			return true
+		}
+	}
+	return false
+}
+
+// RawData returns the header followed by the raw values of all rows.
+// Separators become empty strings and rows that only contain separators, empty strings and nil
+// are omitted. Rows with any other value are included, even if none of their values is a string
+// (e.g. a row of numbers added with AddRow).
+func (t *Table) RawData() [][]any {
+	t.normalize()
+	numRows := t.numRows()
 
 	headers := []any{}
 	for _, col := range t.series {
@@ -158,7 +387,7 @@ func (t *Table) RawData() [][]any {
 		row := []any{}
 		onlySeparators := true
 		for _, col := range t.series {
-			v := col.valuesRaw[i]
+			v := rawValue(col.valuesRaw[i])
 			if str, ok := v.(string); ok {
 				v = strings.TrimSpace(utils.StripANSI(str))
 				if v == "---" {
@@ -166,6 +395,8 @@ func (t *Table) RawData() [][]any {
 				} else if v != "" {
 					onlySeparators = false
 				}
+			} else if v != nil {
+				onlySeparators = false
 			}
 			row = append(row, v)
 		}
@@ -177,20 +408,6 @@ func (t *Table) RawData() [][]any {
 	return rows
 }
 
-func (t *Table) CSV(separator rune) string {
-	rows := []string{}
-
-	for _, cols := range t.RawData() {
-		row := []string{}
-		for _, col := range cols {
-			row = append(row, fmt.Sprint(col))
-		}
-		rows = append(rows, strings.Join(row, string(separator)))
-	}
-
-	return strings.Join(rows, "\n")
-}
-
 func (t *Table) YAML() (string, error) {
 	data, err := yaml.Marshal(t.RawData())
 	if err != nil {
@@ -207,153 +424,326 @@ func (t *Table) JSON() (string, error) {
 	return string(data), nil
 }
 
+// Related config setting(s):
+//
+//   - `LoggerConfig.TableMaxWidth`
 func (t *Table) Print(logger *Logger) {
-	rows := t.Rows()
-	for _, line := range rows {
-		logger.Blank("%s", line)
+	for _, line := range t.render(t.availableWidth(logger.prefixWidth('_')), true) {
+		logger.writeBlock(line)
 	}
 }
 
+// Related config setting(s):
+//
+//   - `LoggerConfig.TableMaxWidth`
 func (t *Table) PrintWithoutHeader(logger *Logger) {
-	rows := t.Rows()
-	rows = append(rows[0:1], rows[3:]...)
-	for _, line := range rows {
-		logger.Blank("%s", line)
+	for _, line := range t.render(t.availableWidth(logger.prefixWidth('_')), false) {
+		logger.writeBlock(line)
 	}
 }
 
+// Related config setting(s):
+//
+//   - `LoggerConfig.TableMaxWidth`
 func (t *Table) Rows() []string {
-	const (
-		NO_VAL    = ""
-		CSEP      = "---"
-		HCONN     = "─"
-		HCONN_B   = "┬"
-		HCONN_T   = "┴"
-		HCONN_C   = "┼"
-		VCONN     = "│"
-		VCONN_R   = "├"
-		VCONN_L   = "┤"
-		VCONN_C   = "┼"
-		CONN_TL   = "┌"
-		CONN_TR   = "┐"
-		CONN_BL   = "└"
-		CONN_BR   = "┘"
-		SPACE     = " "
-		CTYPE_SEP = iota
-		CTYPE_VAL
-		CTYPE_NO_VAL
-	)
-	ls := len(t.series)
-	topRow := make([]string, ls)
-	headers := make([]string, ls)
-	rows := [][]string{} // rows - columns
-	for col, series := range t.series {
-		headers[col] = series.header()
-		topRow[col] = strings.Repeat(HCONN, series.maxLen)
-		for row, v := range series.padValues().values {
-			if len(rows) < row+1 {
-				rows = append(rows, make([]string, ls))
-			}
-			rows[row][col] = v
+	return t.render(t.availableWidth(0), true)
+}
+
+const (
+	tableCellSep = iota
+	tableCellVal
+	tableCellNoVal
+	tableCellSpanned
+)
+
+func (t *Table) render(maxWidth int, withHeader bool) []string {
+	if len(t.series) == 0 {
+		return nil // there is nothing to draw a frame around
+	}
+	border := getBorderSet(t.borderStyle)
+	footer := t.footer()
+	widths := t.columnWidths(maxWidth, footer)
+	heatLo, heatHi := t.heatmapRanges()
+
+	res := t.headerLines(border, widths, withHeader)
+	numRows := t.numRows()
+	dataRow := 0
+	for row := 0; row < numRows; row++ {
+		res = append(res, t.rowLines(border, widths, row, &dataRow, heatLo, heatHi)...)
+	}
+	last := t.rowBoundaries(numRows - 1)
+	if footer != nil {
+		if border.header.fill != "" {
+			res = append(res, border.hline(border.header, widths, last, nil))
 		}
+		res = append(res, t.textLines(border, widths, footer)...)
+		last = nil
 	}
+	return append(res, t.bottomLines(border, widths, last)...)
+}
+
+// textLines renders a row of plain values (like the header or footer) which isn't affected by rules.
+func (t *Table) textLines(border *borderSet, widths []int, values []string) []string {
+	cells := make([][]string, len(t.series))
+	colTypes := make([]int, len(t.series))
+	for colIdx, series := range t.series {
+		cells[colIdx] = series.fit(values[colIdx], widths[colIdx])
+		colTypes[colIdx] = tableCellVal
+	}
+	return t.joinCells(border, cells, colTypes, widths, nil, nil, -1)
+}
+
+// headerLines renders the top border and, if `withHeader` is true, the header.
+// The junctions of the line below the header (or of the top border) match the spans of the first row.
+func (t *Table) headerLines(border *borderSet, widths []int, withHeader bool) []string {
+	res := []string{}
+	first := t.rowBoundaries(0)
+	if border.top.fill != "" {
+		below := first
+		if withHeader {
+			below = nil
+		}
+		res = append(res, border.hline(border.top, widths, make([]bool, max(len(widths)-1, 0)), below))
+	}
+	if withHeader {
+		headers := make([]string, len(t.series))
+		for colIdx, series := range t.series {
+			headers[colIdx] = colorizers.Auto(series.Name)
+		}
+		res = append(res, t.textLines(border, widths, headers)...)
+		if border.header.fill != "" {
+			res = append(res, border.hline(border.header, widths, nil, first))
+		}
+	}
+	return res
+}
+
+// bottomLines renders the bottom border (if the border style has one),
+// `above` are the boundaries of the last row (see rowBoundaries).
+func (t *Table) bottomLines(border *borderSet, widths []int, above []bool) []string {
+	if border.bottom.fill == "" {
+		return nil
+	}
+	return []string{border.hline(border.bottom, widths, above, make([]bool, max(len(widths)-1, 0)))}
+}
+
+// heatmapRanges returns the heatmap ranges of all columns.
+func (t *Table) heatmapRanges() (lo, hi []float64) {
+	lo = make([]float64, len(t.series))
+	hi = make([]float64, len(t.series))
+	for colIdx, series := range t.series {
+		if series.heatmap {
+			lo[colIdx], hi[colIdx] = series.heatmapRange()
+		}
+	}
+	return lo, hi
+}
+
+// rowLines renders the given row, `dataRow` counts the rows that aren't separators (used for striping).
+// Group headers are separated from the surrounding rows by separator lines.
+func (t *Table) rowLines(border *borderSet, widths []int, row int, dataRow *int, heatLo, heatHi []float64) []string {
+	const (
+		NO_VAL = ""
+		CSEP   = "---"
+	)
 	fnColType := func(col, cutset string) int {
 		switch strings.Trim(utils.StripANSI(col), cutset) {
 		case NO_VAL:
-			return CTYPE_NO_VAL
+			return tableCellNoVal
 		case CSEP:
-			return CTYPE_SEP
+			return tableCellSep
 		}
-		return CTYPE_VAL
-	}
-
-	res := []string{
-		CONN_TL + HCONN + strings.Join(topRow, HCONN+HCONN_B+HCONN) + HCONN + CONN_TR,
-		VCONN + SPACE + strings.Join(headers, SPACE+VCONN+SPACE) + SPACE + VCONN,
-		VCONN_R + HCONN + strings.Join(topRow, HCONN+HCONN_C+HCONN) + HCONN + VCONN_L,
-	}
-	for _, row := range rows {
-		colTypes := make([]int, len(row))
-		for colIdx, col := range row {
-			colTypes[colIdx] = fnColType(col, string(t.series[colIdx].padChar))
-			switch colTypes[colIdx] {
-			case CTYPE_NO_VAL:
-				if len(utils.StripANSI(col)) == 0 {
-					switch t.series[colIdx].padDir {
-					case PAD_LEFT:
-						row[colIdx] = utils.PadLeft(colorizers.Auto("N/A"), t.series[colIdx].maxLen, t.series[colIdx].padChar)
-					case PAD_CENTER:
-						row[colIdx] = utils.PadCenter(colorizers.Auto("N/A"), t.series[colIdx].maxLen, t.series[colIdx].padChar)
-					case PAD_RIGHT:
-						row[colIdx] = utils.PadRight(colorizers.Auto("N/A"), t.series[colIdx].maxLen, t.series[colIdx].padChar)
-					}
-				}
-			case CTYPE_SEP:
-				row[colIdx] = utils.PadLeft("", t.series[colIdx].maxLen, '─')
+		return tableCellVal
+	}
+
+	ls := len(t.series)
+	cells := make([][]string, ls)
+	colTypes := make([]int, ls)
+	spans := make([]int, ls)
+	onlySeparators := true
+	for colIdx, series := range t.series {
+		spans[colIdx] = t.span(row, colIdx)
+		if spans[colIdx] == 0 {
+			colTypes[colIdx] = tableCellSpanned
+			continue
+		}
+		width := spanWidth(border, widths, colIdx, spans[colIdx])
+		col := ""
+		if row < len(series.values) {
+			col = series.values[row]
+		}
+		colTypes[colIdx] = fnColType(col, string(series.padChar))
+		switch colTypes[colIdx] {
+		case tableCellNoVal:
+			if row >= len(series.values) {
+				col = colorizers.Auto(TABLE_MISSING_VALUE) // the column has less rows than the table
+			}
+		case tableCellSep:
+			if border.separator.fill == "" || t.collapsedSeparator(row, colIdx) {
+				colTypes[colIdx] = tableCellNoVal // the border style has no separators or the separator is within a collapsed value
+				cells[colIdx] = []string{series.pad("", width)}
+				continue
 			}
+			cells[colIdx] = []string{strings.Repeat(border.separator.fill, width)}
+			continue
+		}
+		onlySeparators = false
+		if t.collapsed(row, colIdx) {
+			cells[colIdx] = []string{series.pad("", width)}
+			continue
+		}
+		col = series.formatCell(row, col, heatLo[colIdx], heatHi[colIdx])
+		if t.isGroupRow(row) { // group titles are always left-aligned
+			left := *series
+			left.padDir = PAD_RIGHT
+			series = &left
 		}
+		cells[colIdx] = series.fit(col, width)
+	}
+	if onlySeparators && border.separator.fill == "" {
+		return nil
+	}
+	background := -1
+	var junctions []string
+	if t.isSeparatorRow(row) {
+		// the junctions of separator lines depend on the spans of the rows above and below
+		above, below := row-1, row+1
+		for above >= 0 && t.isSeparatorRow(above) {
+			above--
+		}
+		for below < t.numRows() && t.isSeparatorRow(below) {
+			below++
+		}
+		up, down := t.rowBoundaries(above), t.rowBoundaries(below)
+		junctions = make([]string, max(ls-1, 0))
+		for i := range junctions {
+			junctions[i] = border.junction(border.separator, up == nil || up[i], down == nil || down[i])
+		}
+	} else {
+		background = t.rowBackground(row, *dataRow)
+		*dataRow++
+	}
+	lines := t.joinCells(border, cells, colTypes, widths, spans, junctions, background)
+	if border.separator.fill != "" && row > 0 && !t.isSeparatorRow(row) && !t.isSeparatorRow(row-1) &&
+		(t.isGroupRow(row) || t.isGroupRow(row-1)) {
+		line := border.hline(border.separator, widths, t.rowBoundaries(row-1), t.rowBoundaries(row))
+		lines = append([]string{line}, lines...)
+	}
+	return lines
+}
 
+// joinCells renders the lines of a single table row, the row is as high as its highest cell.
+// Cells with less lines are aligned according to the vertical alignment of their column.
+// `spans` contains the number of columns each cell covers (nil if all cover one column),
+// `junctions` the junctions between adjacent separator cells (nil to use the default junction).
+// If `background` is not -1, the cells (but not the borders) get that background color.
+func (t *Table) joinCells(border *borderSet, cells [][]string, colTypes []int, widths []int, spans []int, junctions []string, background int) []string {
+	const SPACE = " "
+	height := 0
+	for _, c := range cells {
+		height = max(height, len(c))
+	}
+	offsets := make([]int, len(cells))
+	for colIdx, c := range cells {
+		switch t.series[colIdx].valign {
+		case VALIGN_MIDDLE:
+			offsets[colIdx] = (height - len(c)) / 2
+		case VALIGN_BOTTOM:
+			offsets[colIdx] = height - len(c)
+		}
+	}
+	sep := border.separator
+	lines := []string{}
+	for ln := 0; ln < height; ln++ {
 		rowStr := ""
-		for colIdx, col := range row {
-			currType := colTypes[colIdx]
-
-			// start of row
-			if colIdx == 0 {
-				switch currType {
-				case CTYPE_SEP:
-					rowStr = VCONN_R + HCONN + col + HCONN
-				default:
-					rowStr = VCONN + SPACE + col + SPACE
-				}
+		prevType := -1
+		for colIdx, currType := range colTypes {
+			if currType == tableCellSpanned {
 				continue
 			}
+			span := 1
+			if spans != nil {
+				span = spans[colIdx]
+			}
+			width := spanWidth(border, widths, colIdx, span)
+			col := ""
+			switch cl := ln - offsets[colIdx]; {
+			case cl >= 0 && cl < len(cells[colIdx]):
+				col = cells[colIdx][cl]
+			case currType == tableCellSep:
+				col = strings.Repeat(sep.fill, width)
+			default:
+				col = t.series[colIdx].pad("", width)
+			}
 
-			prevType := colTypes[colIdx-1]
+			// separator cells are padded with the separator line, all others with spaces
+			padding := SPACE
+			if currType == tableCellSep {
+				padding = sep.fill
+			}
 
-			// end of row
-			if colIdx == len(row)-1 {
-				if prevType == CTYPE_SEP {
-					if currType == CTYPE_SEP {
-						rowStr += VCONN_C + HCONN + col + HCONN + VCONN_L
+			last := colIdx+span == len(colTypes)
+			cell := col
+			switch {
+			case prevType < 0: // start of row
+				if border.outer() {
+					if currType == tableCellSep {
+						rowStr += sep.left
 					} else {
-						rowStr += VCONN_L + SPACE + col + SPACE + VCONN
+						rowStr += border.left
 					}
-					continue
+					cell = padding + cell
 				}
-
-				if currType == CTYPE_SEP {
-					rowStr += VCONN_R + HCONN + col + HCONN + VCONN_L
+			case prevType == tableCellSep: // after a separator
+				if currType == tableCellSep {
+					if junctions != nil {
+						rowStr += junctions[colIdx-1]
+					} else {
+						rowStr += sep.junction
+					}
 				} else {
-					rowStr += VCONN + SPACE + col + SPACE + VCONN
+					rowStr += sep.right
 				}
-				continue
-			}
-
-			// somewhere in the middle
-			if prevType == CTYPE_SEP {
-				if currType == CTYPE_SEP {
-					rowStr += VCONN_C + HCONN + col + HCONN
+				cell = padding + cell
+			default:
+				if currType == tableCellSep {
+					rowStr += sep.left
 				} else {
-					rowStr += VCONN_L + SPACE + col + SPACE
+					rowStr += border.middle
 				}
-				continue
+				cell = padding + cell
+			}
+			if !last || border.outer() {
+				cell += padding
 			}
+			if background >= 0 && currType != tableCellSep {
+				cell = withBackground(cell, background)
+			}
+			rowStr += cell
 
-			if currType == CTYPE_SEP {
-				rowStr += VCONN_R + HCONN + col + HCONN
-			} else {
-				rowStr += VCONN + SPACE + col + SPACE
+			if last && border.outer() { // end of row
+				if currType == tableCellSep {
+					rowStr += sep.right
+				} else {
+					rowStr += border.right
+				}
 			}
+			prevType = currType
 		}
-
-		res = append(res, rowStr)
+		lines = append(lines, rowStr)
 	}
-	res = append(res, CONN_BL+HCONN+strings.Join(topRow, HCONN+HCONN_T+HCONN)+HCONN+CONN_BR)
-	return res
+	return lines
 }
 
+// Related config setting(s):
+//
+//   - `LoggerConfig.TableMaxWidth`
+//   - `LoggerConfig.TableBorderStyle`
 func NewTable(columns ...*TableColumn) *Table {
 	return &Table{
-		series: columns,
+		series:      columns,
+		maxWidth:    config.LoggerConfig.TableMaxWidth,
+		borderStyle: config.LoggerConfig.TableBorderStyle,
+		stripes:     -1,
 	}
 }
@@ -0,0 +1,254 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/toxyl/glog/cast"
+	"github.com/toxyl/glog/colorizers"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// AddRow appends a row to the table, the n-th value goes into the n-th column.
+// Columns without a value get nil, values without a column are ignored.
+// Spans (see NewTableSpan) cover multiple columns, the next value goes into the first column after the span.
+func (t *Table) AddRow(values ...any) *Table {
+	t.normalize()
+	spanned := 0
+	for _, col := range t.series {
+		if spanned > 0 {
+			col.Push(tableSpanned{})
+			spanned--
+			continue
+		}
+		if len(values) == 0 {
+			col.Push(nil)
+			continue
+		}
+		v := values[0]
+		values = values[1:]
+		if s, ok := v.(*TableSpan); ok {
+			spanned = s.columns - 1
+		}
+		col.Push(v)
+	}
+	return t
+}
+
+// AddSeparator appends a separator row to the table.
+func (t *Table) AddSeparator() *Table {
+	t.normalize()
+	for _, col := range t.series {
+		col.Push("---")
+	}
+	return t
+}
+
+var (
+	tableHighlightersLock = &sync.Mutex{}
+	tableHighlighters     = map[string]func(a ...any) string{
+		"auto":       colorizers.Auto,
+		"none":       fmt.Sprint,
+		"highlight":  highlightString(colorizers.Highlight),
+		"file":       highlightString(func(s ...string) string { return colorizers.File(strings.Join(s, ", ")) }),
+		"url":        highlightString(colorizers.URL),
+		"bytes":      highlightNumber(func(n float64) string { return colorizers.HumanReadableBytesIEC(n) }),
+		"bytes_si":   highlightNumber(func(n float64) string { return colorizers.HumanReadableBytesSI(n) }),
+		"short":      highlightNumber(func(n float64) string { return colorizers.HumanReadableShort(n) }),
+		"seconds":    highlightNumber(func(n float64) string { return colorizers.DurationShort(n, utils.DURATION_SCALE_AVERAGE) }),
+		"percentage": highlightNumber(func(n float64) string { return colorizers.Percentage(n, config.LoggerConfig.AutoFloatPrecision) }),
+	}
+)
+
+// highlightString adapts a string highlighter, non-string values are highlighted with colorizers.Auto.
+func highlightString(fn func(s ...string) string) func(a ...any) string {
+	return func(a ...any) string {
+		res := []string{}
+		for _, v := range a {
+			if ok, s := cast.String(v); ok {
+				res = append(res, fn(s))
+				continue
+			}
+			res = append(res, colorizers.Auto(v))
+		}
+		return strings.Join(res, ", ")
+	}
+}
+
+// highlightNumber adapts a number highlighter, non-numeric values are highlighted with colorizers.Auto.
+func highlightNumber(fn func(n float64) string) func(a ...any) string {
+	return func(a ...any) string {
+		res := []string{}
+		for _, v := range a {
+			if ok, n := toNumber(v); ok {
+				res = append(res, fn(n))
+				continue
+			}
+			res = append(res, colorizers.Auto(v))
+		}
+		return strings.Join(res, ", ")
+	}
+}
+
+// toNumber converts ints, uints and floats to float64.
+func toNumber(v any) (bool, float64) {
+	if ok, i := cast.Int(v); ok {
+		return true, float64(i)
+	}
+	if ok, u := cast.Uint(v); ok {
+		return true, float64(u)
+	}
+	if ok, _, f := cast.Float(v); ok {
+		return true, f
+	}
+	return false, 0
+}
+
+// RegisterTableHighlighter makes `highlighter` available under the given name
+// for the `highlight` option of `table` struct tags (see NewTableFromStructs).
+func RegisterTableHighlighter(name string, highlighter func(a ...any) string) {
+	tableHighlightersLock.Lock()
+	defer tableHighlightersLock.Unlock()
+	tableHighlighters[name] = highlighter
+}
+
+func tableHighlighter(name string) (func(a ...any) string, error) {
+	tableHighlightersLock.Lock()
+	defer tableHighlightersLock.Unlock()
+	if h, ok := tableHighlighters[name]; ok {
+		return h, nil
+	}
+	return nil, fmt.Errorf("unknown highlighter %q", name)
+}
+
+// tableField describes how a struct field is represented as table column.
+type tableField struct {
+	index       []int
+	name        string
+	padDir      int
+	highlighter func(a ...any) string
+}
+
+func parseTableTag(f reflect.StructField) (*tableField, error) {
+	tf := &tableField{
+		index:  f.Index,
+		name:   f.Name,
+		padDir: PAD_RIGHT,
+	}
+	tag, ok := f.Tag.Lookup("table")
+	if !ok {
+		return tf, nil
+	}
+	if tag == "-" {
+		return nil, nil
+	}
+	opts := strings.Split(tag, ",")
+	if opts[0] != "" {
+		tf.name = opts[0]
+	}
+	for _, opt := range opts[1:] {
+		k, v, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch k {
+		case "align":
+			switch v {
+			case "left":
+				tf.padDir = PAD_RIGHT
+			case "center":
+				tf.padDir = PAD_CENTER
+			case "right":
+				tf.padDir = PAD_LEFT
+			default:
+				return nil, fmt.Errorf("field %s: unknown alignment %q", f.Name, v)
+			}
+		case "highlight":
+			h, err := tableHighlighter(v)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			tf.highlighter = h
+		default:
+			return nil, fmt.Errorf("field %s: unknown option %q", f.Name, k)
+		}
+	}
+	return tf, nil
+}
+
+// NewTableFromStructs creates a table from a slice of structs (or pointers to structs),
+// every exported field becomes a column and every element a row.
+//
+// Columns can be configured with `table` struct tags:
+//
+//	type Disk struct {
+//		Name  string `table:"Disk"`                              // custom header
+//		Size  uint64 `table:"Size,align=right,highlight=bytes"`   // alignment (left, center, right) and highlighter
+//		Model string `table:"-"`                                 // not included
+//	}
+//
+// Available highlighters: auto (default), none, highlight, file, url, bytes, bytes_si, short, seconds, percentage
+// and custom ones registered with RegisterTableHighlighter.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TablePadChar`
+func NewTableFromStructs(data any) (*Table, error) {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", data)
+	}
+	typ := v.Type().Elem()
+	isPtr := typ.Kind() == reflect.Pointer
+	if isPtr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", data)
+	}
+
+	fields := []*tableField{}
+	for _, f := range reflect.VisibleFields(typ) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		tf, err := parseTableTag(f)
+		if err != nil {
+			return nil, err
+		}
+		if tf != nil {
+			fields = append(fields, tf)
+		}
+	}
+
+	columns := []*TableColumn{}
+	for _, f := range fields {
+		columns = append(columns, NewTableColumnCustom(f.name, f.padDir, config.LoggerConfig.TablePadChar, f.highlighter))
+	}
+	t := NewTable(columns...)
+
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if isPtr {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		row := []any{}
+		for _, f := range fields {
+			fv, err := elem.FieldByIndexErr(f.index)
+			if err != nil || (fv.Kind() == reflect.Pointer && fv.IsNil()) {
+				row = append(row, nil) // nil embedded struct or nil pointer field
+				continue
+			}
+			if fv.Kind() == reflect.Pointer {
+				fv = fv.Elem()
+			}
+			row = append(row, fv.Interface())
+		}
+		t.AddRow(row...)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,126 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddRow(t *testing.T) {
+	tbl := NewTable(NewTableColumnLeft("A"), NewTableColumnLeft("B"), NewTableColumnLeft("C")).
+		AddRow(1, 2, 3).
+		AddRow("short").
+		AddRow("x", "y", "z", "ignored").
+		AddSeparator().
+		AddRow(NewTableSpan("wide", 2), 9).
+		AddRow(4, 5, 6)
+
+	got := tbl.RawData()
+	expected := [][]any{
+		{"A", "B", "C"},
+		{1, 2, 3},
+		{"short", nil, nil},
+		{"x", "y", "z"},
+		{"wide", nil, 9},
+		{4, 5, 6},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("RawData() = %#v, want %#v", got, expected)
+	}
+}
+
+func TestRawDataKeepsNonStringRows(t *testing.T) {
+	tbl := NewTable(NewTableColumnLeft("A"), NewTableColumnLeft("B")).
+		AddRow(nil, nil).
+		AddRow(1.5, false).
+		AddRow("", nil)
+
+	got := tbl.RawData()
+	expected := [][]any{{"A", "B"}, {1.5, false}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("RawData() = %#v, want %#v", got, expected)
+	}
+}
+
+type builderDisk struct {
+	Name    string  `table:"Disk"`
+	Size    uint64  `table:"Size,align=right,highlight=bytes"`
+	Model   string  `table:"-"`
+	Mount   *string `table:",align=center"`
+	Healthy bool
+	serial  string
+}
+
+func TestNewTableFromStructs(t *testing.T) {
+	mount := "/data"
+	disks := []*builderDisk{
+		{Name: "sda", Size: 1024, Model: "x", Mount: &mount, Healthy: true, serial: "s1"},
+		nil,
+		{Name: "sdb", Size: 2048},
+	}
+	tbl, err := NewTableFromStructs(disks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := tbl.RawData()
+	expected := [][]any{
+		{"Disk", "Size", "Mount", "Healthy"},
+		{"sda", uint64(1024), "/data", true},
+		{"sdb", uint64(2048), nil, false},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("RawData() = %#v, want %#v", got, expected)
+	}
+
+	padDirs := []int{PAD_RIGHT, PAD_LEFT, PAD_CENTER, PAD_RIGHT}
+	for i, col := range tbl.series {
+		if col.padDir != padDirs[i] {
+			t.Errorf("column %s: padDir = %d, want %d", col.Name, col.padDir, padDirs[i])
+		}
+	}
+	if got := tbl.series[1].values[0]; !strings.Contains(got, "KiB") {
+		t.Errorf("Size column = %q, want bytes highlighting", got)
+	}
+}
+
+func TestNewTableFromStructsErrors(t *testing.T) {
+	tests := []struct {
+		data     any
+		expected string
+	}{
+		{
+			data:     []int{1, 2},
+			expected: "expected a slice of structs",
+		},
+		{
+			data:     struct{ A int }{1},
+			expected: "expected a slice of structs",
+		},
+		{
+			data: []struct {
+				A int `table:"A,align=top"`
+			}{},
+			expected: `field A: unknown alignment "top"`,
+		},
+		{
+			data: []struct {
+				A int `table:"A,highlight=rainbow"`
+			}{},
+			expected: `field A: unknown highlighter "rainbow"`,
+		},
+		{
+			data: []struct {
+				A int `table:"A,width=3"`
+			}{},
+			expected: `field A: unknown option "width"`,
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := NewTableFromStructs(tt.data)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("NewTableFromStructs(%T) error = %v, want %q", tt.data, err, tt.expected)
+		}
+	}
+}
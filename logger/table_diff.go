@@ -0,0 +1,197 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/colorizers"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+const (
+	TABLE_DIFF_ADDED     = "+"
+	TABLE_DIFF_REMOVED   = "-"
+	TABLE_DIFF_CHANGED   = "~"
+	TABLE_DIFF_UNCHANGED = " "
+)
+
+// TableDiff is the result of DiffTables.
+type TableDiff struct {
+	Table     *Table // combined table, the first column contains the markers (TABLE_DIFF_*)
+	Added     int
+	Removed   int
+	Changed   int
+	Unchanged int
+}
+
+// Summary returns a line with the number of added, removed, changed and unchanged rows.
+func (d *TableDiff) Summary() string {
+	return fmt.Sprintf("%s added, %s removed, %s changed, %s unchanged",
+		colorizers.WrapGreen(fmt.Sprint(d.Added)),
+		colorizers.WrapRed(fmt.Sprint(d.Removed)),
+		colorizers.WrapYellow(fmt.Sprint(d.Changed)),
+		colorizers.Auto(d.Unchanged),
+	)
+}
+
+// Print prints the combined table followed by the summary line.
+func (d *TableDiff) Print(logger *Logger) {
+	d.Table.Print(logger)
+	logger.Blank("%s", d.Summary())
+}
+
+// strikeThrough strikes through all parts of `str`, keeping their colors.
+func strikeThrough(str string) string {
+	spans := ansi.Parse(str)
+	for i := range spans {
+		spans[i].Style.StrikeThrough = true
+	}
+	return ansi.Render(spans)
+}
+
+// diffRows returns the rows of `t` (see RawData) as maps from column name to value.
+func diffRows(t *Table) []map[string]any {
+	data := t.RawData()
+	res := []map[string]any{}
+	for _, row := range data[1:] {
+		m := map[string]any{}
+		for i, v := range row {
+			m[t.series[i].Name] = v
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+// DiffTables compares two snapshots of a table and returns a combined table that marks
+// added (TABLE_DIFF_ADDED), removed (TABLE_DIFF_REMOVED) and changed (TABLE_DIFF_CHANGED) rows.
+// Rows are matched by the value of `keyColumn`, which must exist in both tables.
+//
+// Changed cells show the old value struck-through followed by the new value in color,
+// removed rows are struck-through. Rows are in the order of `newTable`, removed rows are inserted
+// where they were in `oldTable`. The table has the columns of `newTable` followed by those only `oldTable` has,
+// only columns that exist in both tables are compared.
+//
+// Values are compared like when sorting tables (see Table.SortByKeys), separators are ignored.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TablePadChar`
+func DiffTables(oldTable, newTable *Table, keyColumn string) (*TableDiff, error) {
+	oldKey, newKey := oldTable.columnIndex(keyColumn), newTable.columnIndex(keyColumn)
+	if oldKey < 0 || newKey < 0 {
+		return nil, fmt.Errorf("key column %q must exist in both tables", keyColumn)
+	}
+
+	// columns of the new table first, then those that have been removed
+	sources := []*TableColumn{}
+	shared := map[string]bool{}
+	for _, col := range newTable.series {
+		sources = append(sources, col)
+		shared[col.Name] = oldTable.columnIndex(col.Name) >= 0
+	}
+	for _, col := range oldTable.series {
+		if newTable.columnIndex(col.Name) < 0 {
+			sources = append(sources, col)
+		}
+	}
+	marker := NewTableColumnCustom("", PAD_RIGHT, config.LoggerConfig.TablePadChar, nil)
+	columns := []*TableColumn{marker}
+	for _, src := range sources {
+		columns = append(columns, NewTableColumnCustom(src.Name, src.padDir, src.padChar, src.fnHighlight))
+	}
+	diff := &TableDiff{Table: NewTable(columns...)}
+
+	addRow := func(mark string, fnMark func(string) string, cell func(src *TableColumn) (any, string)) {
+		marker.pushHighlighted(mark, fnMark(mark))
+		for i, src := range sources {
+			columns[i+1].pushHighlighted(cell(src))
+		}
+	}
+	addRemoved := func(row map[string]any) {
+		diff.Removed++
+		addRow(TABLE_DIFF_REMOVED, colorizers.WrapRed, func(src *TableColumn) (any, string) {
+			v, ok := row[src.Name]
+			if !ok {
+				return nil, "" // column only exists in the new table
+			}
+			return v, strikeThrough(src.fnHighlight(v))
+		})
+	}
+
+	oldRows, newRows := diffRows(oldTable), diffRows(newTable)
+	keyOf := func(row map[string]any) string { return fmt.Sprint(row[keyColumn]) }
+	newKeys := map[string]int{}
+	for _, row := range newRows {
+		newKeys[keyOf(row)]++
+	}
+	oldIndex := map[string][]int{} // duplicate keys are matched in order
+	removed := make([]bool, len(oldRows))
+	for i, row := range oldRows {
+		k := keyOf(row)
+		if newKeys[k] > len(oldIndex[k]) {
+			oldIndex[k] = append(oldIndex[k], i)
+			continue
+		}
+		removed[i] = true
+	}
+	nextOld := 0 // removed rows before this index have been added already
+	for _, row := range newRows {
+		k := keyOf(row)
+		if len(oldIndex[k]) == 0 {
+			diff.Added++
+			addRow(TABLE_DIFF_ADDED, colorizers.WrapGreen, func(src *TableColumn) (any, string) {
+				v, ok := row[src.Name]
+				if !ok {
+					return nil, "" // column only exists in the old table
+				}
+				return v, src.fnHighlight(v)
+			})
+			continue
+		}
+		oldIdx := oldIndex[k][0]
+		oldIndex[k] = oldIndex[k][1:]
+		for ; nextOld < oldIdx; nextOld++ {
+			if removed[nextOld] {
+				addRemoved(oldRows[nextOld])
+			}
+		}
+
+		oldRow := oldRows[oldIdx]
+		changed := false
+		for _, src := range sources {
+			if shared[src.Name] && compareValues(oldRow[src.Name], row[src.Name]) != 0 {
+				changed = true
+			}
+		}
+		if !changed {
+			diff.Unchanged++
+			addRow(TABLE_DIFF_UNCHANGED, func(s string) string { return s }, func(src *TableColumn) (any, string) {
+				v, ok := row[src.Name]
+				if !ok {
+					v = oldRow[src.Name] // column only exists in the old table
+				}
+				return v, src.fnHighlight(v)
+			})
+			continue
+		}
+		diff.Changed++
+		addRow(TABLE_DIFF_CHANGED, colorizers.WrapYellow, func(src *TableColumn) (any, string) {
+			v, ok := row[src.Name]
+			if !ok {
+				v = oldRow[src.Name]
+			}
+			if !shared[src.Name] || compareValues(oldRow[src.Name], v) == 0 {
+				return v, src.fnHighlight(v)
+			}
+			return v, strikeThrough(src.fnHighlight(oldRow[src.Name])) + " " + colorizers.WrapGreen(utils.StripANSI(src.fnHighlight(v)))
+		})
+	}
+	for ; nextOld < len(oldRows); nextOld++ {
+		if removed[nextOld] {
+			addRemoved(oldRows[nextOld])
+		}
+	}
+	return diff, nil
+}
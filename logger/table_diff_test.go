@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/toxyl/glog/utils"
+)
+
+func TestDiffTables(t *testing.T) {
+	oldTable := NewTable(NewTableColumnLeft("Name"), NewTableColumnRight("Size"), NewTableColumnLeft("Owner")).
+		AddRow("a", 1, "root").
+		AddRow("b", 2, "root").
+		AddRow("c", 3, "root").
+		AddSeparator().
+		AddRow("d", 4, "root")
+	newTable := NewTable(NewTableColumnLeft("Name"), NewTableColumnRight("Size"), NewTableColumnLeft("Mode")).
+		AddRow("a", 1, "rw").
+		AddRow("c", 30, "ro").
+		AddRow("e", 5, "rw").
+		AddRow("d", 4, "ro")
+
+	diff, err := DiffTables(oldTable, newTable, "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]any{
+		{"", "Name", "Size", "Mode", "Owner"},
+		{"", "a", 1, "rw", "root"},
+		{TABLE_DIFF_REMOVED, "b", 2, nil, "root"},
+		{TABLE_DIFF_CHANGED, "c", 30, "ro", "root"},
+		{TABLE_DIFF_ADDED, "e", 5, "rw", nil},
+		{"", "d", 4, "ro", "root"},
+	}
+	// the unchanged marker is a space, RawData returns it as empty string
+	if got := diff.Table.RawData(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Table.RawData() = %#v, want %#v", got, expected)
+	}
+
+	counts := []int{diff.Added, diff.Removed, diff.Changed, diff.Unchanged}
+	if !reflect.DeepEqual(counts, []int{1, 1, 1, 2}) {
+		t.Errorf("added, removed, changed, unchanged = %v, want [1 1 1 2]", counts)
+	}
+
+	// changed cells show the old and the new value, removed rows are struck-through
+	size := diff.Table.series[2].values
+	if got := utils.StripANSI(size[2]); got != "3 30" {
+		t.Errorf("changed cell = %q, want %q", got, "3 30")
+	}
+	if !strings.Contains(size[1], "\033[9") {
+		t.Errorf("removed cell = %q, want strike-through", size[1])
+	}
+	if strings.Contains(size[0], "\033[9") {
+		t.Errorf("unchanged cell = %q, want no strike-through", size[0])
+	}
+
+	if got := utils.StripANSI(diff.Summary()); got != "1 added, 1 removed, 1 changed, 2 unchanged" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+func TestDiffTablesDuplicateKeys(t *testing.T) {
+	oldTable := NewTable(NewTableColumnLeft("Name"), NewTableColumnRight("Size")).
+		AddRow("x", 1).
+		AddRow("x", 2)
+	newTable := NewTable(NewTableColumnLeft("Name"), NewTableColumnRight("Size")).
+		AddRow("x", 1).
+		AddRow("y", 3)
+
+	diff, err := DiffTables(oldTable, newTable, "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := columnValues(diff.Table, 0)
+	expected := []any{TABLE_DIFF_UNCHANGED, TABLE_DIFF_ADDED, TABLE_DIFF_REMOVED}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("markers = %#v, want %#v", got, expected)
+	}
+}
+
+func TestDiffTablesMissingKey(t *testing.T) {
+	a := NewTable(NewTableColumnLeft("Name")).AddRow("a")
+	b := NewTable(NewTableColumnLeft("ID")).AddRow("a")
+	if _, err := DiffTables(a, b, "Name"); err == nil {
+		t.Error("DiffTables() with a key column missing in the new table returned no error")
+	}
+}
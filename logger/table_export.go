@@ -0,0 +1,350 @@
+package logger
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/utils"
+)
+
+const (
+	CSV_DURATION_STRING       = iota // e.g. 1m30s
+	CSV_DURATION_SECONDS             // e.g. 90 or 1.5
+	CSV_DURATION_MILLISECONDS        // e.g. 90000
+)
+
+// TableCSVOptions controls how a Table is exported as CSV (see Table.WriteCSV).
+type TableCSVOptions struct {
+	Separator      rune   // field separator, e.g. ',' or '\t'
+	Header         bool   // include the column names as first row
+	TimeFormat     string // layout used for time.Time values
+	DurationFormat int    // one of CSV_DURATION_STRING, CSV_DURATION_SECONDS or CSV_DURATION_MILLISECONDS
+	FloatPrecision int    // number of decimals for floats, -1 uses as many as needed
+}
+
+// NewTableCSVOptions returns options for comma separated values with header,
+// RFC 3339 timestamps, durations like "1m30s" and floats with as many decimals as needed.
+func NewTableCSVOptions() *TableCSVOptions {
+	return &TableCSVOptions{
+		Separator:      ',',
+		Header:         true,
+		TimeFormat:     time.RFC3339,
+		DurationFormat: CSV_DURATION_STRING,
+		FloatPrecision: -1,
+	}
+}
+
+func (o *TableCSVOptions) format(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case time.Time:
+		return val.Format(o.TimeFormat)
+	case time.Duration:
+		switch o.DurationFormat {
+		case CSV_DURATION_SECONDS:
+			return strconv.FormatFloat(val.Seconds(), 'f', o.FloatPrecision, 64)
+		case CSV_DURATION_MILLISECONDS:
+			return strconv.FormatInt(val.Milliseconds(), 10)
+		}
+		return val.String()
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', o.FloatPrecision, 32)
+	case float64:
+		return strconv.FormatFloat(val, 'f', o.FloatPrecision, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// WriteCSV writes the table as RFC 4180 compliant CSV to `w`,
+// values containing separators, quotes or line breaks are quoted.
+// Separator rows are omitted. If `opts` is nil, the defaults of NewTableCSVOptions are used.
+func (t *Table) WriteCSV(w io.Writer, opts *TableCSVOptions) error {
+	if opts == nil {
+		opts = NewTableCSVOptions()
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = opts.Separator
+	rows := t.RawData()
+	if !opts.Header {
+		rows = rows[1:]
+	}
+	for _, cols := range rows {
+		record := []string{}
+		for _, v := range cols {
+			record = append(record, opts.format(v))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// CSVWithOptions returns the table as CSV, see WriteCSV.
+func (t *Table) CSVWithOptions(opts *TableCSVOptions) (string, error) {
+	var sb strings.Builder
+	if err := t.WriteCSV(&sb, opts); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// CSV returns the table as CSV using the given separator and the defaults of NewTableCSVOptions.
+//
+// The only possible error is an invalid separator (e.g. a quote, line break or the Unicode
+// replacement character), in that case an empty string is returned. Use CSVWithOptions
+// to get the error instead.
+func (t *Table) CSV(separator rune) string {
+	opts := NewTableCSVOptions()
+	opts.Separator = separator
+	res, err := t.CSVWithOptions(opts)
+	if err != nil {
+		return ""
+	}
+	return res
+}
+
+// TSV returns the table as tab separated values, see CSV. It never fails as tabs are valid separators.
+func (t *Table) TSV() string {
+	return t.CSV('\t')
+}
+
+// tableExportCell is a cell of an exported table (see Table.exportRows).
+type tableExportCell struct {
+	col   int    // index of the first column the cell covers
+	span  int    // number of columns the cell covers
+	group bool   // the cell is a group title (see Table.AddGroup)
+	text  string // plain text, empty for missing values
+	value string // highlighted text (including rules and heatmap colors), empty for missing values
+}
+
+// exportRows returns the cells of all rows that aren't separators, cells covered by spans are omitted.
+// Like RawData, rows without any value are omitted.
+func (t *Table) exportRows() [][]tableExportCell {
+	t.normalize()
+	heatLo, heatHi := t.heatmapRanges()
+	rows := [][]tableExportCell{}
+	for row := 0; row < t.numRows(); row++ {
+		if t.isSeparatorRow(row) {
+			continue
+		}
+		cells := []tableExportCell{}
+		empty := true
+		for colIdx, series := range t.series {
+			span := t.span(row, colIdx)
+			if span == 0 {
+				continue
+			}
+			cell := tableExportCell{col: colIdx, span: span, group: t.isGroupRow(row)}
+			v := rawValue(series.valuesRaw[row])
+			if str, ok := v.(string); ok {
+				v = strings.TrimSpace(utils.StripANSI(str))
+				if v == "" {
+					v = nil
+				}
+			}
+			if v != nil {
+				cell.text = fmt.Sprint(v)
+				cell.value = series.formatCell(row, series.values[row], heatLo[colIdx], heatHi[colIdx])
+				empty = false
+			}
+			cells = append(cells, cell)
+		}
+		if !empty {
+			rows = append(rows, cells)
+		}
+	}
+	return rows
+}
+
+// Markdown returns the table as GitHub flavored Markdown (pipe table).
+// The pad direction of the columns is used as alignment,
+// line breaks within values are converted to `<br>`.
+// Markdown has no spans, their values are shown in the first column they cover.
+// A table without columns returns an empty string.
+func (t *Table) Markdown() string {
+	if len(t.series) == 0 {
+		return ""
+	}
+	escape := func(str string) string {
+		str = strings.ReplaceAll(str, "|", `\|`)
+		return strings.ReplaceAll(str, "\n", "<br>")
+	}
+
+	rows := [][]string{}
+	header := []string{}
+	for _, col := range t.series {
+		header = append(header, col.Name)
+	}
+	rows = append(rows, header)
+	for _, cells := range t.exportRows() {
+		row := make([]string, len(t.series))
+		for _, cell := range cells {
+			row[cell.col] = cell.text
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(t.series))
+	for _, row := range rows {
+		for i, v := range row {
+			row[i] = escape(v)
+			widths[i] = max(widths[i], utils.StringWidth(row[i]), 3)
+		}
+	}
+
+	delimiters := make([]string, len(t.series))
+	for i, col := range t.series {
+		switch col.padDir {
+		case PAD_LEFT:
+			delimiters[i] = strings.Repeat("-", widths[i]-1) + ":"
+		case PAD_CENTER:
+			delimiters[i] = ":" + strings.Repeat("-", widths[i]-2) + ":"
+		default:
+			delimiters[i] = ":" + strings.Repeat("-", widths[i]-1)
+		}
+	}
+
+	line := func(cells []string) string {
+		for i, v := range cells {
+			switch t.series[i].padDir {
+			case PAD_LEFT:
+				cells[i] = utils.PadLeft(v, widths[i], ' ')
+			case PAD_CENTER:
+				cells[i] = utils.PadCenter(v, widths[i], ' ')
+			default:
+				cells[i] = utils.PadRight(v, widths[i], ' ')
+			}
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	lines := []string{line(rows[0]), "| " + strings.Join(delimiters, " | ") + " |"}
+	for _, row := range rows[1:] {
+		lines = append(lines, line(row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// HTML returns the table as HTML table. If `colors` is true, values are
+// highlighted like in the terminal (using inline styles), otherwise they are plain text.
+// The pad direction of the columns is used as alignment,
+// line breaks within values are converted to `<br>`.
+// Spans and group titles (see NewTableSpan and Table.AddGroup) become cells with a colspan.
+// A table without columns returns an empty string, a table without rows has no body.
+func (t *Table) HTML(colors bool) string {
+	if len(t.series) == 0 {
+		return ""
+	}
+	align := func(col *TableColumn) string {
+		switch col.padDir {
+		case PAD_LEFT:
+			return ` style="text-align:right"`
+		case PAD_CENTER:
+			return ` style="text-align:center"`
+		}
+		return ` style="text-align:left"`
+	}
+	text := func(str string, colored bool) string {
+		if colored {
+			str = ansi.ToHTML(str, nil)
+		} else {
+			str = html.EscapeString(str)
+		}
+		return strings.ReplaceAll(str, "\n", "<br>")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n<thead>\n<tr>")
+	for _, col := range t.series {
+		fmt.Fprintf(&sb, "<th%s>%s</th>", align(col), text(col.Name, false))
+	}
+	sb.WriteString("</tr>\n</thead>\n")
+
+	if rows := t.exportRows(); len(rows) > 0 {
+		sb.WriteString("<tbody>\n")
+		for _, cells := range rows {
+			sb.WriteString("<tr>")
+			for _, cell := range cells {
+				attrs := align(t.series[cell.col])
+				if cell.group { // group titles are always left-aligned
+					attrs = ` style="text-align:left"`
+				}
+				if cell.span > 1 {
+					attrs = fmt.Sprintf(` colspan="%d"`, cell.span) + attrs
+				}
+				v := cell.text
+				if colors {
+					v = cell.value
+				}
+				fmt.Fprintf(&sb, "<td%s>%s</td>", attrs, text(v, colors))
+			}
+			sb.WriteString("</tr>\n")
+		}
+		sb.WriteString("</tbody>\n")
+	}
+
+	sb.WriteString("</table>")
+	return sb.String()
+}
+
+// AsciiDoc returns the table as AsciiDoc table with a header row.
+// The pad direction of the columns is used as alignment,
+// line breaks within values are converted to hard line breaks.
+// Spans and group titles (see NewTableSpan and Table.AddGroup) become cells that span columns.
+// A table without columns returns an empty string.
+func (t *Table) AsciiDoc() string {
+	if len(t.series) == 0 {
+		return ""
+	}
+	escape := func(str string) string {
+		str = strings.ReplaceAll(str, "|", `\|`)
+		return strings.ReplaceAll(str, "\n", " +\n")
+	}
+
+	cols := []string{}
+	header := []string{}
+	for _, col := range t.series {
+		switch col.padDir {
+		case PAD_LEFT:
+			cols = append(cols, ">")
+		case PAD_CENTER:
+			cols = append(cols, "^")
+		default:
+			cols = append(cols, "<")
+		}
+		header = append(header, "|"+escape(col.Name))
+	}
+
+	lines := []string{
+		fmt.Sprintf(`[cols="%s",options="header"]`, strings.Join(cols, ",")),
+		"|===",
+		strings.Join(header, " "),
+	}
+	for _, row := range t.exportRows() {
+		cells := []string{}
+		for _, cell := range row {
+			spec := ""
+			if cell.span > 1 {
+				spec = fmt.Sprintf("%d+", cell.span)
+			}
+			if cell.group { // group titles are always left-aligned
+				spec += "<"
+			}
+			cells = append(cells, spec+"|"+escape(cell.text))
+		}
+		lines = append(lines, strings.Join(cells, " "))
+	}
+	lines = append(lines, "|===")
+	return strings.Join(lines, "\n")
+}
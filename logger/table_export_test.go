@@ -0,0 +1,278 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVWithOptions(t *testing.T) {
+	newTable := func(values ...any) *Table {
+		return NewTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Value")).AddRow(values...)
+	}
+	seconds := func(o *TableCSVOptions) { o.DurationFormat = CSV_DURATION_SECONDS }
+
+	tests := []struct {
+		name     string
+		table    *Table
+		modify   func(o *TableCSVOptions)
+		expected string
+	}{
+		{
+			name:     "plain values",
+			table:    newTable("a", 1),
+			expected: "Name,Value\na,1",
+		},
+		{
+			name:     "embedded quotes",
+			table:    newTable(`say "hi"`, 1),
+			expected: "Name,Value\n\"say \"\"hi\"\"\",1",
+		},
+		{
+			name:     "embedded separators",
+			table:    newTable("a,b", "c;d"),
+			expected: "Name,Value\n\"a,b\",c;d",
+		},
+		{
+			name:     "embedded newlines",
+			table:    newTable("line 1\nline 2", 1),
+			expected: "Name,Value\n\"line 1\nline 2\",1",
+		},
+		{
+			name:     "custom separator quotes its own character only",
+			table:    newTable("a;b", "c,d"),
+			modify:   func(o *TableCSVOptions) { o.Separator = ';' },
+			expected: "Name;Value\n\"a;b\";c,d",
+		},
+		{
+			name:     "nil values are empty",
+			table:    newTable("a", nil),
+			expected: "Name,Value\na,",
+		},
+		{
+			name:     "ANSI escapes are stripped",
+			table:    newTable("\033[38;5;45mblue\033[0m", "\033[1mbold, too\033[0m"),
+			expected: "Name,Value\nblue,\"bold, too\"",
+		},
+		{
+			name:     "separators are omitted",
+			table:    newTable("a", 1).AddSeparator().AddRow("b", 2),
+			expected: "Name,Value\na,1\nb,2",
+		},
+		{
+			name:     "without header",
+			table:    newTable("a", 1),
+			modify:   func(o *TableCSVOptions) { o.Header = false },
+			expected: "a,1",
+		},
+		{
+			name:     "duration as string",
+			table:    newTable("d", 90*time.Second),
+			expected: "Name,Value\nd,1m30s",
+		},
+		{
+			name:     "duration in seconds",
+			table:    newTable("d", 1500*time.Millisecond),
+			modify:   seconds,
+			expected: "Name,Value\nd,1.5",
+		},
+		{
+			name:  "duration in seconds with float precision",
+			table: newTable("d", 1500*time.Millisecond),
+			modify: func(o *TableCSVOptions) {
+				seconds(o)
+				o.FloatPrecision = 2
+			},
+			expected: "Name,Value\nd,1.50",
+		},
+		{
+			name:     "duration in milliseconds",
+			table:    newTable("d", 90*time.Second),
+			modify:   func(o *TableCSVOptions) { o.DurationFormat = CSV_DURATION_MILLISECONDS },
+			expected: "Name,Value\nd,90000",
+		},
+		{
+			name:     "times",
+			table:    newTable("t", time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)),
+			modify:   func(o *TableCSVOptions) { o.TimeFormat = time.DateOnly },
+			expected: "Name,Value\nt,2024-05-06",
+		},
+		{
+			name:     "floats",
+			table:    newTable(0.1, float32(2.5)),
+			expected: "Name,Value\n0.1,2.5",
+		},
+	}
+
+	for _, tt := range tests {
+		opts := NewTableCSVOptions()
+		if tt.modify != nil {
+			tt.modify(opts)
+		}
+		got, err := tt.table.CSVWithOptions(opts)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%s: CSVWithOptions() = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestCSVInvalidSeparator(t *testing.T) {
+	tbl := NewTable(NewTableColumnLeft("Name")).AddRow("a")
+	for _, sep := range []rune{'"', '\n', '\r', '�'} {
+		opts := NewTableCSVOptions()
+		opts.Separator = sep
+		if _, err := tbl.CSVWithOptions(opts); err == nil {
+			t.Errorf("CSVWithOptions(separator %q) returned no error", sep)
+		}
+		if got := tbl.CSV(sep); got != "" {
+			t.Errorf("CSV(%q) = %q, want empty string", sep, got)
+		}
+	}
+}
+
+func TestTSV(t *testing.T) {
+	tbl := NewTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Value")).
+		AddRow("tab\there", "a,b").
+		AddRow("x", nil)
+
+	expected := "Name\tValue\n\"tab\there\"\ta,b\nx\t"
+	if got := tbl.TSV(); got != expected {
+		t.Errorf("TSV() = %q, want %q", got, expected)
+	}
+	if !strings.Contains(tbl.CSV(','), `"a,b"`) {
+		t.Errorf("CSV(',') = %q, want quoted separator", tbl.CSV(','))
+	}
+}
+
+func TestTableMarkupExports(t *testing.T) {
+	newTable := func() *Table {
+		return NewTable(NewTableColumnLeft("Name"), NewTableColumnRight("Size"), NewTableColumnCenter("State"))
+	}
+	full := newTable().
+		AddRow("a|b", 1, nil).
+		AddSeparator().
+		AddGroup("Group").
+		AddRow("x", NewTableSpan("both", 2)).
+		AddRow("two\nlines", 2.5, "ok")
+	header := `<tr><th style="text-align:left">Name</th><th style="text-align:right">Size</th><th style="text-align:center">State</th></tr>`
+
+	tests := []struct {
+		name     string
+		table    *Table
+		export   func(t *Table) string
+		expected string
+	}{
+		{
+			name:   "markdown",
+			table:  full,
+			export: (*Table).Markdown,
+			expected: strings.Join([]string{
+				"| Name         | Size | State |",
+				"| :----------- | ---: | :---: |",
+				`| a\|b         |    1 |       |`,
+				"| Group        |      |       |",
+				"| x            | both |       |",
+				"| two<br>lines |  2.5 |  ok   |",
+			}, "\n"),
+		},
+		{
+			name:     "markdown without rows",
+			table:    newTable(),
+			export:   (*Table).Markdown,
+			expected: "| Name | Size | State |\n| :--- | ---: | :---: |",
+		},
+		{
+			name:     "markdown without columns",
+			table:    NewTable(),
+			export:   (*Table).Markdown,
+			expected: "",
+		},
+		{
+			name:   "html",
+			table:  full,
+			export: func(t *Table) string { return t.HTML(false) },
+			expected: strings.Join([]string{
+				"<table>",
+				"<thead>",
+				header,
+				"</thead>",
+				"<tbody>",
+				`<tr><td style="text-align:left">a|b</td><td style="text-align:right">1</td><td style="text-align:center"></td></tr>`,
+				`<tr><td colspan="3" style="text-align:left">Group</td></tr>`,
+				`<tr><td style="text-align:left">x</td><td colspan="2" style="text-align:right">both</td></tr>`,
+				`<tr><td style="text-align:left">two<br>lines</td><td style="text-align:right">2.5</td><td style="text-align:center">ok</td></tr>`,
+				"</tbody>",
+				"</table>",
+			}, "\n"),
+		},
+		{
+			name:     "html without rows",
+			table:    newTable(),
+			export:   func(t *Table) string { return t.HTML(false) },
+			expected: "<table>\n<thead>\n" + header + "\n</thead>\n</table>",
+		},
+		{
+			name:     "html without columns",
+			table:    NewTable(),
+			export:   func(t *Table) string { return t.HTML(true) },
+			expected: "",
+		},
+		{
+			name:   "asciidoc",
+			table:  full,
+			export: (*Table).AsciiDoc,
+			expected: strings.Join([]string{
+				`[cols="<,>,^",options="header"]`,
+				"|===",
+				"|Name |Size |State",
+				`|a\|b |1 |`,
+				"3+<|Group",
+				"|x 2+|both",
+				"|two +",
+				"lines |2.5 |ok",
+				"|===",
+			}, "\n"),
+		},
+		{
+			name:     "asciidoc without rows",
+			table:    newTable(),
+			export:   (*Table).AsciiDoc,
+			expected: "[cols=\"<,>,^\",options=\"header\"]\n|===\n|Name |Size |State\n|===",
+		},
+		{
+			name:     "asciidoc without columns",
+			table:    NewTable(),
+			export:   (*Table).AsciiDoc,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.export(tt.table); got != tt.expected {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestTableHTMLWithColors(t *testing.T) {
+	tbl := NewTable(NewTableColumnLeft("Name"), NewTableColumnRight("Size"), NewTableColumnCenter("State")).
+		AddRow("a", 1, nil).
+		AddRow("b", NewTableSpan("both", 2))
+
+	got := tbl.HTML(true)
+	for _, want := range []string{
+		`<td style="text-align:center"></td>`, // nil values are empty, like without colors
+		`<td colspan="2" style="text-align:right"><span style=`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML(true) = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "nil") {
+		t.Errorf("HTML(true) = %q, want no \"nil\"", got)
+	}
+}
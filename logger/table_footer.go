@@ -0,0 +1,212 @@
+package logger
+
+import (
+	"time"
+
+	"github.com/toxyl/glog/cast"
+	"github.com/toxyl/glog/colorizers"
+)
+
+// TableAggregate computes the footer value of a column from its raw values (separators excluded).
+type TableAggregate interface {
+	// Aggregate returns the aggregated value or nil if there is none (rendered as empty cell).
+	Aggregate(values []any) any
+	// UsesColumnHighlighter returns true if the result has the meaning of the column's values
+	// (e.g. a sum of bytes) and is rendered with the column's highlighter,
+	// otherwise it's highlighted according to its type (e.g. a count).
+	UsesColumnHighlighter() bool
+}
+
+// TableAggregateFunc is a TableAggregate whose results are rendered with the column's highlighter.
+type TableAggregateFunc func(values []any) any
+
+func (fn TableAggregateFunc) Aggregate(values []any) any  { return fn(values) }
+func (fn TableAggregateFunc) UsesColumnHighlighter() bool { return true }
+
+type tableAggregate struct {
+	fn                func(values []any) any
+	columnHighlighter bool
+}
+
+func (a *tableAggregate) Aggregate(values []any) any  { return a.fn(values) }
+func (a *tableAggregate) UsesColumnHighlighter() bool { return a.columnHighlighter }
+
+// NewTableAggregate returns a TableAggregate that computes its result with `fn`.
+// If `columnHighlighter` is false, results are highlighted according to their type
+// instead of with the column's highlighter.
+func NewTableAggregate(fn func(values []any) any, columnHighlighter bool) TableAggregate {
+	return &tableAggregate{fn: fn, columnHighlighter: columnHighlighter}
+}
+
+// aggregateHighlighter returns the highlighter for the results of `aggregate` in a column with the given highlighter.
+func aggregateHighlighter(aggregate TableAggregate, column func(a ...any) string) func(a ...any) string {
+	if aggregate.UsesColumnHighlighter() {
+		return column
+	}
+	return colorizers.Auto
+}
+
+// aggregateValues returns the values of the column that can be aggregated,
+// missing values (nil and empty strings), separators and spans are excluded.
+func (t *TableColumn) aggregateValues() []any {
+	res := []any{}
+	for _, v := range t.valuesRaw {
+		if isSeparatorValue(v) {
+			continue
+		}
+		switch v.(type) {
+		case *TableSpan, tableSpanned:
+			continue
+		}
+		if rank, _ := sortRank(v); rank == sortRankNil {
+			continue
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
+// SetAggregate sets the aggregate that computes the value shown in the table's footer for this column,
+// e.g. AggregateSum. The value is rendered with the column's highlighter if the aggregate uses it
+// (see TableAggregate.UsesColumnHighlighter), empty results (nil) are rendered as empty cell.
+// The footer is shown if at least one column has an aggregate.
+func (t *TableColumn) SetAggregate(aggregate TableAggregate) *TableColumn {
+	t.aggregate = aggregate
+	return t
+}
+
+// AggregateSum returns the sum of all numeric values.
+// The result is an int64 if all values are ints, a uint64 if all values are uints,
+// a time.Duration if all values are durations and a float64 otherwise. Non-numeric values are ignored.
+var AggregateSum TableAggregateFunc = aggregateSum
+
+func aggregateSum(values []any) any {
+	var (
+		sumInt      int64
+		sumUint     uint64
+		sumFloat    float64
+		sumDuration time.Duration
+		numInt      int
+		numUint     int
+		numFloat    int
+		numDuration int
+	)
+	for _, v := range values {
+		if ok, d := cast.Duration(v); ok {
+			sumDuration += d
+			numDuration++
+			continue
+		}
+		if ok, i := cast.Int(v); ok {
+			sumInt += i
+			numInt++
+			continue
+		}
+		if ok, u := cast.Uint(v); ok {
+			sumUint += u
+			numUint++
+			continue
+		}
+		if ok, _, f := cast.Float(v); ok {
+			sumFloat += f
+			numFloat++
+		}
+	}
+	total := numInt + numUint + numFloat + numDuration
+	switch total {
+	case 0:
+		return nil
+	case numInt:
+		return sumInt
+	case numUint:
+		return sumUint
+	case numDuration:
+		return sumDuration
+	}
+	return sumFloat + float64(sumInt) + float64(sumUint) + sumDuration.Seconds()
+}
+
+// AggregateAvg returns the average of all numeric values as float64,
+// or as time.Duration if all values are durations. Non-numeric values are ignored.
+var AggregateAvg TableAggregateFunc = aggregateAvg
+
+func aggregateAvg(values []any) any {
+	n := 0
+	for _, v := range values {
+		if ok, _ := toNumber(v); ok {
+			n++
+		} else if ok, _ := cast.Duration(v); ok {
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	switch sum := aggregateSum(values).(type) {
+	case time.Duration:
+		return sum / time.Duration(n)
+	default:
+		_, f := toNumber(sum)
+		return f / float64(n)
+	}
+}
+
+// AggregateMin returns the smallest value, values are compared like when sorting tables (see Table.SortByKeys).
+var AggregateMin TableAggregateFunc = aggregateMin
+
+func aggregateMin(values []any) any {
+	var res any
+	for i, v := range values {
+		if i == 0 || compareValues(v, res) < 0 {
+			res = v
+		}
+	}
+	return res
+}
+
+// AggregateMax returns the largest value, values are compared like when sorting tables (see Table.SortByKeys).
+var AggregateMax TableAggregateFunc = aggregateMax
+
+func aggregateMax(values []any) any {
+	var res any
+	for i, v := range values {
+		if i == 0 || compareValues(v, res) > 0 {
+			res = v
+		}
+	}
+	return res
+}
+
+// AggregateCount returns the number of values.
+// The count is highlighted as number, not with the column's highlighter.
+var AggregateCount = NewTableAggregate(func(values []any) any {
+	return len(values)
+}, false)
+
+// AggregateText returns an aggregate that always shows `text`, e.g. to label the footer.
+func AggregateText(text string) TableAggregate {
+	return TableAggregateFunc(func(values []any) any {
+		return text
+	})
+}
+
+// footer returns the highlighted footer values or nil if no column has an aggregate.
+func (t *Table) footer() []string {
+	hasFooter := false
+	for _, col := range t.series {
+		hasFooter = hasFooter || col.aggregate != nil
+	}
+	if !hasFooter {
+		return nil
+	}
+	res := make([]string, len(t.series))
+	for i, col := range t.series {
+		if col.aggregate == nil {
+			continue
+		}
+		if v := col.aggregate.Aggregate(col.aggregateValues()); v != nil {
+			res[i] = aggregateHighlighter(col.aggregate, col.fnHighlight)(v)
+		}
+	}
+	return res
+}
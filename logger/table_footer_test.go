@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/toxyl/glog/utils"
+)
+
+func TestAggregates(t *testing.T) {
+	tests := []struct {
+		name      string
+		aggregate TableAggregate
+		values    []any
+		expected  any
+	}{
+		{name: "sum of ints", aggregate: AggregateSum, values: []any{1, int8(2), 3}, expected: int64(6)},
+		{name: "sum of uints", aggregate: AggregateSum, values: []any{uint(1), uint16(2)}, expected: uint64(3)},
+		{name: "sum of durations", aggregate: AggregateSum, values: []any{time.Second, time.Minute}, expected: 61 * time.Second},
+		{name: "sum of mixed numbers", aggregate: AggregateSum, values: []any{1, 0.5, "x"}, expected: 1.5},
+		{name: "sum without numbers", aggregate: AggregateSum, values: []any{"x"}, expected: nil},
+		{name: "average", aggregate: AggregateAvg, values: []any{1, 2, 6}, expected: 3.0},
+		{name: "average of durations", aggregate: AggregateAvg, values: []any{time.Second, 3 * time.Second}, expected: 2 * time.Second},
+		{name: "average without numbers", aggregate: AggregateAvg, values: []any{}, expected: nil},
+		{name: "min", aggregate: AggregateMin, values: []any{3, 1, 2}, expected: 1},
+		{name: "max of strings", aggregate: AggregateMax, values: []any{"b", "C", "a"}, expected: "C"},
+		{name: "min without values", aggregate: AggregateMin, values: []any{}, expected: nil},
+		{name: "count", aggregate: AggregateCount, values: []any{"a", 1}, expected: 2},
+		{name: "text", aggregate: AggregateText("Total"), values: []any{1}, expected: "Total"},
+	}
+
+	for _, tt := range tests {
+		got := tt.aggregate.Aggregate(tt.values)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: Aggregate(%#v) = %#v, want %#v", tt.name, tt.values, got, tt.expected)
+		}
+	}
+}
+
+func TestTableFooter(t *testing.T) {
+	bytes := func(a ...any) string { return fmt.Sprint(a...) + " B" }
+	newColumn := func(aggregate TableAggregate) *TableColumn {
+		return NewTableColumnRightCustom("Size", ' ', bytes).SetAggregate(aggregate)
+	}
+
+	tbl := NewTable(
+		NewTableColumnLeft("Name").SetAggregate(AggregateText("Total")),
+		newColumn(AggregateSum),
+		newColumn(AggregateCount),
+		newColumn(NewTableAggregate(func(values []any) any { return len(values) * 2 }, false)),
+		newColumn(TableAggregateFunc(func(values []any) any { return len(values) * 2 })),
+		NewTableColumnLeft("Empty").SetAggregate(AggregateMin),
+		NewTableColumnLeft("None"),
+	).
+		AddRow("a", 1, 1, 1, 1, nil).
+		AddSeparator().
+		AddRow("b", 2, 2, 2, 2, "")
+
+	got := tbl.footer()
+	for i := range got {
+		got[i] = utils.StripANSI(got[i])
+	}
+	expected := []string{"Total", "3 B", "2", "4", "4 B", "", ""}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("footer() = %q, want %q", got, expected)
+	}
+
+	if got := NewTable(NewTableColumnLeft("Name")).AddRow("a").footer(); got != nil {
+		t.Errorf("footer() without aggregates = %q, want nil", got)
+	}
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"math"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/cast"
+	"github.com/toxyl/glog/colormap"
+	"github.com/toxyl/glog/utils"
+)
+
+// tableRule colors cells whose raw value matches.
+type tableRule struct {
+	match func(v any) bool
+	color int
+}
+
+// tableRowRule colors the background of rows whose raw values match.
+type tableRowRule struct {
+	match      func(row []any) bool
+	background int
+}
+
+// AddRule colors the values of the column for which `match` returns true with `color`
+// (an index of glog's color table, e.g. colormap.Red), replacing the colors of the highlighter.
+// Rules are evaluated in the order they were added when rendering, the first matching rule wins.
+// Rules take precedence over the heatmap (see SetHeatmap).
+//
+// Example:
+//
+//	col.AddRule(func(v any) bool { n, ok := v.(int); return ok && n > 90 }, colormap.Red)
+func (t *TableColumn) AddRule(match func(v any) bool, color int) *TableColumn {
+	t.rules = append(t.rules, tableRule{match: match, color: color})
+	return t
+}
+
+// SetHeatmap colors the numeric values (including durations) of the column relative to
+// the column's min and max value, using the colors of glog's color table between `from` (min) and `to` (max).
+// As glog's color table is ordered for smooth transitions, this results in a gradient.
+// If all values are equal, they get the `from` color. NaN and infinite values keep the colors of the highlighter.
+func (t *TableColumn) SetHeatmap(from, to int) *TableColumn {
+	t.heatmap = true
+	t.heatmapFrom = from
+	t.heatmapTo = to
+	return t
+}
+
+// heatValue converts numbers and durations (as seconds) to float64.
+// NaN and infinite values are not part of the heatmap.
+func heatValue(v any) (bool, float64) {
+	if ok, d := cast.Duration(v); ok {
+		return true, d.Seconds()
+	}
+	ok, f := toNumber(v)
+	return ok && !math.IsNaN(f) && !math.IsInf(f, 0), f
+}
+
+// heatmapRange returns the min and max of the column's numeric values.
+func (t *TableColumn) heatmapRange() (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range t.valuesRaw {
+		if ok, f := heatValue(v); ok {
+			lo = min(lo, f)
+			hi = max(hi, f)
+		}
+	}
+	return lo, hi
+}
+
+// formatCell applies the rules and heatmap of the column to the highlighted value `str` of the given row.
+// `lo` and `hi` are the heatmap range of the column.
+func (t *TableColumn) formatCell(row int, str string, lo, hi float64) string {
+	if row >= len(t.valuesRaw) {
+		return str
+	}
+	v := rawValue(t.valuesRaw[row])
+	for _, r := range t.rules {
+		if r.match(v) {
+			return ansi.Wrap(utils.StripANSI(str), r.color).String()
+		}
+	}
+	if ok, f := heatValue(v); ok && t.heatmap {
+		pos := 0.0
+		if hi > lo {
+			pos = (f - lo) / (hi - lo)
+		}
+		color := t.heatmapFrom + int(math.Round(pos*float64(t.heatmapTo-t.heatmapFrom)))
+		return ansi.Wrap(utils.StripANSI(str), color).String()
+	}
+	return str
+}
+
+// SetStripes colors the background of every other row with `background`
+// (an index of glog's color table) to make wide tables easier to read. Use -1 to disable striping.
+func (t *Table) SetStripes(background int) *Table {
+	t.stripes = background
+	return t
+}
+
+// HighlightRows colors the background of rows for which `match` returns true with `background`
+// (an index of glog's color table). `match` receives the raw values of the row.
+// Row highlights are evaluated in the order they were added when rendering,
+// the first matching one wins and takes precedence over stripes (see SetStripes).
+func (t *Table) HighlightRows(match func(row []any) bool, background int) *Table {
+	t.rowRules = append(t.rowRules, tableRowRule{match: match, background: background})
+	return t
+}
+
+// rowBackground returns the background color of the given row or -1 if it has none.
+// `dataRow` is the index of the row not counting separator rows.
+func (t *Table) rowBackground(row, dataRow int) int {
+	if len(t.rowRules) > 0 {
+		values := make([]any, len(t.series))
+		for i, col := range t.series {
+			if row < len(col.valuesRaw) {
+				values[i] = rawValue(col.valuesRaw[row])
+			}
+		}
+		for _, r := range t.rowRules {
+			if r.match(values) {
+				return r.background
+			}
+		}
+	}
+	if t.stripes >= 0 && dataRow%2 == 1 {
+		return t.stripes
+	}
+	return -1
+}
+
+// withBackground sets the background color (an index of glog's color table) of all parts of `str` without background.
+func withBackground(str string, background int) string {
+	spans := ansi.Parse(str)
+	for i := range spans {
+		if spans[i].Style.Bg < 0 {
+			spans[i].Style.Bg = colormap.MapColor(background)
+		}
+	}
+	return ansi.Render(spans)
+}
@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/colormap"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+func TestFormatCell(t *testing.T) {
+	withConfig(t, func(c *config.Config) { c.ColorsDisabled = false })
+	above := func(n int) func(v any) bool {
+		return func(v any) bool { i, ok := v.(int); return ok && i > n }
+	}
+
+	tests := []struct {
+		name     string
+		column   *TableColumn
+		values   []any
+		expected []int // color of each value, -1 if the highlighter's colors are kept
+	}{
+		{
+			name:     "rules",
+			column:   NewTableColumnLeft("v").AddRule(above(1), 1),
+			values:   []any{1, 2, "x"},
+			expected: []int{-1, 1, -1},
+		},
+		{
+			name:     "first matching rule wins",
+			column:   NewTableColumnLeft("v").AddRule(above(2), 3).AddRule(above(1), 1),
+			values:   []any{2, 3},
+			expected: []int{1, 3},
+		},
+		{
+			name:     "heatmap",
+			column:   NewTableColumnLeft("v").SetHeatmap(10, 20),
+			values:   []any{0, 25, 50, 100},
+			expected: []int{10, 13, 15, 20},
+		},
+		{
+			name:     "heatmap of durations",
+			column:   NewTableColumnLeft("v").SetHeatmap(10, 20),
+			values:   []any{time.Second, 3 * time.Second},
+			expected: []int{10, 20},
+		},
+		{
+			name:     "rules take precedence over the heatmap",
+			column:   NewTableColumnLeft("v").SetHeatmap(10, 20).AddRule(above(50), 1),
+			values:   []any{0, 100},
+			expected: []int{10, 1},
+		},
+		{
+			name:     "heatmap of constant values",
+			column:   NewTableColumnLeft("v").SetHeatmap(10, 20),
+			values:   []any{5, 5},
+			expected: []int{10, 10},
+		},
+		{
+			name:     "heatmap ignores NaN, infinity and nil",
+			column:   NewTableColumnLeft("v").SetHeatmap(10, 20),
+			values:   []any{1.0, math.NaN(), math.Inf(1), nil, 3.0},
+			expected: []int{10, -1, -1, -1, 20},
+		},
+		{
+			name:     "heatmap without numbers",
+			column:   NewTableColumnLeft("v").SetHeatmap(10, 20),
+			values:   []any{"a", nil},
+			expected: []int{-1, -1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.column.Push(tt.values...)
+		lo, hi := tt.column.heatmapRange()
+		for row, v := range tt.values {
+			str := tt.column.values[row]
+			want := str
+			if tt.expected[row] >= 0 {
+				want = ansi.Wrap(utils.StripANSI(str), tt.expected[row]).String()
+			}
+			if got := tt.column.formatCell(row, str, lo, hi); got != want {
+				t.Errorf("%s: formatCell(%v) = %q, want %q", tt.name, v, got, want)
+			}
+		}
+	}
+}
+
+func TestRowBackground(t *testing.T) {
+	isB := func(row []any) bool { return row[0] == "b" }
+
+	tests := []struct {
+		name     string
+		table    *Table
+		expected []int // background of each row, separators don't count as rows for stripes
+	}{
+		{
+			name:     "none",
+			table:    NewTable(NewTableColumnLeft("v")),
+			expected: []int{-1, -1, -1, -1, -1},
+		},
+		{
+			name:     "stripes",
+			table:    NewTable(NewTableColumnLeft("v")).SetStripes(5),
+			expected: []int{-1, 5, -1, -1, 5},
+		},
+		{
+			name:     "highlighted rows take precedence over stripes",
+			table:    NewTable(NewTableColumnLeft("v")).SetStripes(5).HighlightRows(isB, 7),
+			expected: []int{-1, 7, -1, -1, 5},
+		},
+		{
+			name:     "first matching highlight wins",
+			table:    NewTable(NewTableColumnLeft("v")).HighlightRows(isB, 7).HighlightRows(func(row []any) bool { return true }, 8),
+			expected: []int{8, 7, -1, 8, 8},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.table.AddRow("a").AddRow("b").AddSeparator().AddRow("c").AddRow("d")
+		got := []int{}
+		dataRow := 0
+		for row := 0; row < tt.table.numRows(); row++ {
+			if tt.table.isSeparatorRow(row) {
+				got = append(got, -1)
+				continue
+			}
+			got = append(got, tt.table.rowBackground(row, dataRow))
+			dataRow++
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: backgrounds = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestRenderBackground(t *testing.T) {
+	withConfig(t, func(c *config.Config) {
+		c.ColorsDisabled = false
+		c.TableBorderStyle = BORDER_LIGHT
+	})
+	tbl := NewTable(NewTableColumnLeft("Name"), NewTableColumnRight("Size")).
+		SetStripes(5).
+		AddRow("a", 1).
+		AddRow("b", 2)
+	lines := tbl.render(0, true)
+
+	tests := []struct {
+		line       int
+		background int
+	}{
+		{line: 3, background: -1}, // a
+		{line: 4, background: colormap.MapColor(5)},
+	}
+	for _, tt := range tests {
+		for _, sp := range ansi.Parse(lines[tt.line]) {
+			want := tt.background
+			if sp.Text == "│" {
+				want = -1 // borders never get a background
+			}
+			if sp.Style.Bg != want {
+				t.Errorf("line %q: background of %q = %d, want %d", utils.StripANSI(lines[tt.line]), sp.Text, sp.Style.Bg, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package logger
+
+import (
+	"github.com/toxyl/glog/colorizers"
+	"github.com/toxyl/glog/utils"
+)
+
+// TableSpan is a value that spans multiple columns of a row, see NewTableSpan.
+type TableSpan struct {
+	value   any
+	columns int
+	group   bool
+}
+
+// tableSpanned marks cells that are covered by a TableSpan of a previous column.
+type tableSpanned struct{}
+
+// NewTableSpan creates a value that spans `columns` columns when added to a table with Table.AddRow,
+// the next value of the row goes into the first column after the span.
+// The value is rendered with the highlighter and alignment of the first column it covers.
+//
+// Example:
+//
+//	t.AddRow("web-01", glog.NewTableSpan("maintenance", 2), 42)
+func NewTableSpan(value any, columns int) *TableSpan {
+	return &TableSpan{value: value, columns: max(columns, 1)}
+}
+
+// Value returns the value of the span.
+func (s *TableSpan) Value() any {
+	return s.value
+}
+
+// rawValue returns the value of spans and nil for spanned cells, all other values are returned unchanged.
+func rawValue(v any) any {
+	switch val := v.(type) {
+	case *TableSpan:
+		return val.value
+	case tableSpanned:
+		return nil
+	}
+	return v
+}
+
+// AddGroup appends a group header row to the table, the title spans all columns.
+// Unless the group is the first row or follows a separator, a separator line is drawn above it,
+// and a separator line is drawn between the group header and its rows.
+// Like separators, group headers divide the table into sections when sorting (see SortByKeys)
+// and they reset collapsed values (see TableColumn.SetCollapse).
+func (t *Table) AddGroup(title any) *Table {
+	return t.AddRow(&TableSpan{value: title, columns: len(t.series), group: true})
+}
+
+// SetCollapse determines whether values that are equal to the value of the previous row are hidden,
+// so that the value appears to span all rows it's repeated in. This is meant for grouping columns
+// (e.g. host, then service) and works best if the table is sorted by the column.
+// Separator lines between collapsed values are not drawn in the column.
+func (t *TableColumn) SetCollapse(collapse bool) *TableColumn {
+	t.collapse = collapse
+	return t
+}
+
+// highlightValue highlights `v` with the column's highlighter, spans use the highlighter of their first column
+// (group titles are always highlighted automatically) and spanned cells are empty.
+func (t *TableColumn) highlightValue(v any) string {
+	switch val := v.(type) {
+	case *TableSpan:
+		if val.group {
+			return colorizers.Auto(val.value)
+		}
+		return t.fnHighlight(val.value)
+	case tableSpanned:
+		return ""
+	}
+	return t.fnHighlight(v)
+}
+
+// span returns the number of columns the cell at the given row and column covers:
+// 1 for normal cells, more for spans and 0 for spanned cells.
+func (t *Table) span(row, col int) int {
+	series := t.series[col]
+	if row >= len(series.valuesRaw) {
+		return 1
+	}
+	switch val := series.valuesRaw[row].(type) {
+	case *TableSpan:
+		return max(min(val.columns, len(t.series)-col), 1)
+	case tableSpanned:
+		return 0
+	}
+	return 1
+}
+
+// isGroupRow returns true if the row is a group header (see AddGroup).
+func (t *Table) isGroupRow(row int) bool {
+	if len(t.series) == 0 || row < 0 || row >= len(t.series[0].valuesRaw) {
+		return false
+	}
+	s, ok := t.series[0].valuesRaw[row].(*TableSpan)
+	return ok && s.group
+}
+
+// rowBoundaries returns for every pair of adjacent columns whether the row has a vertical border between them.
+// Rows that don't exist return nil, which is treated like a row without spans.
+func (t *Table) rowBoundaries(row int) []bool {
+	if row < 0 || row >= t.numRows() || len(t.series) == 0 {
+		return nil
+	}
+	res := make([]bool, len(t.series)-1)
+	for i := range res {
+		res[i] = t.span(row, i+1) > 0
+	}
+	return res
+}
+
+// spanWidth returns the width of a cell starting at column `col` that covers `span` columns,
+// including the borders and padding between the covered columns.
+func spanWidth(border *borderSet, widths []int, col, span int) int {
+	w := 0
+	for i := col; i < col+span; i++ {
+		w += widths[i]
+	}
+	return w + (span-1)*(utils.StringWidth(border.middle)+2)
+}
+
+// collapsed returns true if the value at the given row equals the value of the previous row
+// in a column with collapsing enabled. Separator rows are skipped, group headers end the comparison.
+func (t *Table) collapsed(row, col int) bool {
+	series := t.series[col]
+	if !series.collapse || row >= len(series.values) || t.span(row, col) != 1 {
+		return false
+	}
+	for prev := row - 1; prev >= 0; prev-- {
+		if t.isGroupRow(prev) {
+			return false
+		}
+		if t.isSeparatorRow(prev) {
+			continue
+		}
+		if t.span(prev, col) != 1 {
+			return false
+		}
+		v := utils.StripANSI(series.values[row])
+		return v != "" && v == utils.StripANSI(series.values[prev])
+	}
+	return false
+}
+
+// collapsedSeparator returns true if a separator cell in the given row and column lies within
+// a collapsed value, i.e. the next data row collapses into the previous one.
+func (t *Table) collapsedSeparator(row, col int) bool {
+	for next := row + 1; next < t.numRows(); next++ {
+		if t.isSeparatorRow(next) {
+			continue
+		}
+		return t.collapsed(next, col)
+	}
+	return false
+}
+
+// hline draws the horizontal line `l` for columns of the given widths. `above` and `below` are the
+// boundaries of the rows above and below the line (see rowBoundaries), they determine
+// whether the junction of two columns connects upwards, downwards, both or neither.
+func (b *borderSet) hline(l borderLine, widths []int, above, below []bool) string {
+	junctions := make([]string, max(len(widths)-1, 0))
+	for i := range junctions {
+		junctions[i] = b.junction(l, above == nil || above[i], below == nil || below[i])
+	}
+	return l.lineWith(widths, junctions, b.outer())
+}
+
+// junction returns the character of the line `l` where the vertical border between two columns
+// ends above (`up`) and/or below (`down`) the line.
+func (b *borderSet) junction(l borderLine, up, down bool) string {
+	switch {
+	case up && down:
+		return l.junction
+	case up && b.bottom.fill == l.fill && b.bottom.junction != "":
+		return b.bottom.junction
+	case down && b.top.fill == l.fill && b.top.junction != "":
+		return b.top.junction
+	case !up && !down:
+		return l.fill
+	}
+	return l.junction
+}
+
+// growSpans widens the columns covered by spans that don't fit into them,
+// starting with the last covered column. Columns aren't grown beyond their max width.
+func (t *Table) growSpans(border *borderSet, widths []int) {
+	for row := 0; row < t.numRows(); row++ {
+		for col := range t.series {
+			span := t.span(row, col)
+			if span < 2 {
+				continue
+			}
+			missing := linesWidth(t.series[col].values[row]) - spanWidth(border, widths, col, span)
+			for i := col + span - 1; i >= col && missing > 0; i-- {
+				grow := missing
+				if mw := t.series[i].maxWidth; mw > 0 {
+					grow = min(grow, max(mw-widths[i], 0))
+				}
+				widths[i] += grow
+				missing -= grow
+			}
+		}
+	}
+}
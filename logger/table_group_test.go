@@ -0,0 +1,227 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBorderJunction(t *testing.T) {
+	tests := []struct {
+		style    int
+		expected [4]string // up and down, only up, only down, neither
+	}{
+		{style: BORDER_LIGHT, expected: [4]string{"┼", "┴", "┬", "─"}},
+		{style: BORDER_HEAVY, expected: [4]string{"╋", "┻", "┳", "━"}},
+		{style: BORDER_DOUBLE, expected: [4]string{"╬", "╩", "╦", "═"}},
+		{style: BORDER_ROUNDED, expected: [4]string{"┼", "┴", "┬", "─"}},
+		{style: BORDER_ASCII, expected: [4]string{"+", "+", "+", "-"}},
+		{style: BORDER_MARKDOWN, expected: [4]string{"|", "|", "|", "-"}},
+		{style: BORDER_COMPACT, expected: [4]string{" ", " ", " ", "─"}},
+	}
+
+	for _, tt := range tests {
+		b := getBorderSet(tt.style)
+		got := [4]string{
+			b.junction(b.separator, true, true),
+			b.junction(b.separator, true, false),
+			b.junction(b.separator, false, true),
+			b.junction(b.separator, false, false),
+		}
+		if got != tt.expected {
+			t.Errorf("style %d: junctions = %q, want %q", tt.style, got, tt.expected)
+		}
+	}
+}
+
+// TestTableGroupsRender covers group headers, spans and collapsed values: the lines around group headers
+// only connect to the columns of the data rows (┴ above, ┬ below), separators below spans only have
+// junctions where the next row has a border (┬) and separator cells within collapsed values aren't drawn.
+func TestTableGroupsRender(t *testing.T) {
+	tests := []struct {
+		style    int
+		expected []string
+	}{
+		{
+			style: BORDER_LIGHT,
+			expected: []string{
+				"┌──────┬─────────┬──────┐",
+				"│ Host │ Service │ Port │",
+				"├──────┴─────────┴──────┤",
+				"│ prod                  │",
+				"├──────┬─────────┬──────┤",
+				"│ web  │ http    │   80 │",
+				"│      │ https   │  443 │",
+				"│ db   │ maintenance    │",
+				"│      ├────────────────┤",
+				"│      │ offline        │",
+				"│      ├─────────┬──────┤",
+				"│      │ sql     │ 5432 │",
+				"├──────┴─────────┴──────┤",
+				"│ dev                   │",
+				"├──────┬─────────┬──────┤",
+				"│ web  │ http    │ 8080 │",
+				"└──────┴─────────┴──────┘",
+			},
+		},
+		{
+			style: BORDER_HEAVY,
+			expected: []string{
+				"┏━━━━━━┳━━━━━━━━━┳━━━━━━┓",
+				"┃ Host ┃ Service ┃ Port ┃",
+				"┣━━━━━━┻━━━━━━━━━┻━━━━━━┫",
+				"┃ prod                  ┃",
+				"┣━━━━━━┳━━━━━━━━━┳━━━━━━┫",
+				"┃ web  ┃ http    ┃   80 ┃",
+				"┃      ┃ https   ┃  443 ┃",
+				"┃ db   ┃ maintenance    ┃",
+				"┃      ┣━━━━━━━━━━━━━━━━┫",
+				"┃      ┃ offline        ┃",
+				"┃      ┣━━━━━━━━━┳━━━━━━┫",
+				"┃      ┃ sql     ┃ 5432 ┃",
+				"┣━━━━━━┻━━━━━━━━━┻━━━━━━┫",
+				"┃ dev                   ┃",
+				"┣━━━━━━┳━━━━━━━━━┳━━━━━━┫",
+				"┃ web  ┃ http    ┃ 8080 ┃",
+				"┗━━━━━━┻━━━━━━━━━┻━━━━━━┛",
+			},
+		},
+		{
+			style: BORDER_DOUBLE,
+			expected: []string{
+				"╔══════╦═════════╦══════╗",
+				"║ Host ║ Service ║ Port ║",
+				"╠══════╩═════════╩══════╣",
+				"║ prod                  ║",
+				"╠══════╦═════════╦══════╣",
+				"║ web  ║ http    ║   80 ║",
+				"║      ║ https   ║  443 ║",
+				"║ db   ║ maintenance    ║",
+				"║      ╠════════════════╣",
+				"║      ║ offline        ║",
+				"║      ╠═════════╦══════╣",
+				"║      ║ sql     ║ 5432 ║",
+				"╠══════╩═════════╩══════╣",
+				"║ dev                   ║",
+				"╠══════╦═════════╦══════╣",
+				"║ web  ║ http    ║ 8080 ║",
+				"╚══════╩═════════╩══════╝",
+			},
+		},
+		{
+			style: BORDER_ROUNDED,
+			expected: []string{
+				"╭──────┬─────────┬──────╮",
+				"│ Host │ Service │ Port │",
+				"├──────┴─────────┴──────┤",
+				"│ prod                  │",
+				"├──────┬─────────┬──────┤",
+				"│ web  │ http    │   80 │",
+				"│      │ https   │  443 │",
+				"│ db   │ maintenance    │",
+				"│      ├────────────────┤",
+				"│      │ offline        │",
+				"│      ├─────────┬──────┤",
+				"│      │ sql     │ 5432 │",
+				"├──────┴─────────┴──────┤",
+				"│ dev                   │",
+				"├──────┬─────────┬──────┤",
+				"│ web  │ http    │ 8080 │",
+				"╰──────┴─────────┴──────╯",
+			},
+		},
+		{
+			style: BORDER_ASCII,
+			expected: []string{
+				"+------+---------+------+",
+				"| Host | Service | Port |",
+				"+------+---------+------+",
+				"| prod                  |",
+				"+------+---------+------+",
+				"| web  | http    |   80 |",
+				"|      | https   |  443 |",
+				"| db   | maintenance    |",
+				"|      +----------------+",
+				"|      | offline        |",
+				"|      +---------+------+",
+				"|      | sql     | 5432 |",
+				"+------+---------+------+",
+				"| dev                   |",
+				"+------+---------+------+",
+				"| web  | http    | 8080 |",
+				"+------+---------+------+",
+			},
+		},
+		{
+			style: BORDER_MARKDOWN,
+			expected: []string{
+				"| Host | Service | Port |",
+				"|------|---------|------|",
+				"| prod                  |",
+				"|------|---------|------|",
+				"| web  | http    |   80 |",
+				"|      | https   |  443 |",
+				"| db   | maintenance    |",
+				"|      |----------------|",
+				"|      | offline        |",
+				"|      |---------|------|",
+				"|      | sql     | 5432 |",
+				"|------|---------|------|",
+				"| dev                   |",
+				"|------|---------|------|",
+				"| web  | http    | 8080 |",
+			},
+		},
+		{
+			style: BORDER_NONE,
+			expected: []string{
+				"Host   Service   Port",
+				"prod                 ",
+				"web    http        80",
+				"       https      443",
+				"db     maintenance   ",
+				"       offline       ",
+				"       sql       5432",
+				"dev                  ",
+				"web    http      8080",
+			},
+		},
+		{
+			style: BORDER_COMPACT,
+			expected: []string{
+				"Host   Service   Port",
+				"───── ───────── ─────",
+				"prod                 ",
+				"───── ───────── ─────",
+				"web    http        80",
+				"       https      443",
+				"db     maintenance   ",
+				"      ───────────────",
+				"       offline       ",
+				"      ───────── ─────",
+				"       sql       5432",
+				"───── ───────── ─────",
+				"dev                  ",
+				"───── ───────── ─────",
+				"web    http      8080",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tbl := NewTable(NewTableColumnLeft("Host").SetCollapse(true), NewTableColumnLeft("Service"), NewTableColumnRight("Port")).
+			SetBorderStyle(tt.style).
+			AddGroup("prod").
+			AddRow("web", "http", 80).
+			AddRow("web", "https", 443).
+			AddRow("db", NewTableSpan("maintenance", 2)).
+			AddSeparator().
+			AddRow("db", NewTableSpan("offline", 2)).
+			AddSeparator().
+			AddRow("db", "sql", 5432).
+			AddGroup("dev").
+			AddRow("web", "http", 8080)
+		if got := plainLines(tbl.render(0, true)); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("style %d: render() = %q, want %q", tt.style, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// tableTimeLayouts are the layouts tried when inferring whether a column contains times.
+var tableTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05.999999999 -0700 MST", // fmt.Sprint(time.Time)
+	time.DateTime,
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// hasLeadingZeros returns true if the integer part of the number `s` is zero-padded (e.g. "007" or "-00.5").
+func hasLeadingZeros(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	return len(s) > 1 && s[0] == '0' && s[1] >= '0' && s[1] <= '9'
+}
+
+// tableTextParsers infer the types that JSON and YAML can only represent as strings.
+var tableTextParsers = []func(s string) (any, bool){
+	func(s string) (any, bool) {
+		d, err := time.ParseDuration(s)
+		return d, err == nil
+	},
+	func(s string) (any, bool) {
+		for _, layout := range tableTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true
+			}
+		}
+		return nil, false
+	},
+}
+
+// tableValueParsers are tried in order when inferring the type of a column,
+// the first parser that accepts all values of the column wins.
+// Zero-padded numbers (e.g. IDs like "007") are not converted, so they keep their padding.
+var tableValueParsers = append([]func(s string) (any, bool){
+	func(s string) (any, bool) {
+		i, err := strconv.Atoi(s)
+		return i, err == nil && strconv.Itoa(i) == s
+	},
+	func(s string) (any, bool) {
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) && !hasLeadingZeros(s)
+	},
+	func(s string) (any, bool) {
+		switch strings.ToLower(s) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+		return nil, false
+	},
+}, tableTextParsers...)
+
+// inferColumn converts the values of a column that only contains strings
+// to the first type of `parsers` all of them can be parsed as. Empty strings become nil.
+// Columns with values of other types are returned unchanged (except for empty strings).
+func inferColumn(values []any, parsers []func(s string) (any, bool)) []any {
+	res := make([]any, len(values))
+	strs := make([]string, len(values))
+	allStrings := true
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			allStrings = allStrings && v == nil
+			res[i] = v
+			continue
+		}
+		strs[i] = strings.TrimSpace(s)
+		if strs[i] != "" {
+			res[i] = s
+		}
+	}
+	if !allStrings {
+		return res
+	}
+
+	for _, parse := range parsers {
+		parsed := make([]any, len(values))
+		ok := true
+		for i, s := range strs {
+			if s == "" {
+				continue
+			}
+			if parsed[i], ok = parse(s); !ok {
+				break
+			}
+		}
+		if ok {
+			return parsed
+		}
+	}
+	return res
+}
+
+// isNumericColumn returns true if all values of the column are numbers or durations.
+func isNumericColumn(values []any) bool {
+	numeric := false
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		if _, ok := v.(time.Duration); !ok {
+			if ok, _ := toNumber(v); !ok {
+				return false
+			}
+		}
+		numeric = true
+	}
+	return numeric
+}
+
+// newTableFromRecords creates a table, the types of string columns are inferred with `parsers`
+// and numeric columns are right-aligned. Missing headers are named "Column <n>".
+func newTableFromRecords(headers []string, records [][]any, parsers []func(s string) (any, bool)) *Table {
+	numCols := len(headers)
+	for _, r := range records {
+		numCols = max(numCols, len(r))
+	}
+
+	columns := make([]*TableColumn, numCols)
+	values := make([][]any, numCols)
+	for col := 0; col < numCols; col++ {
+		colValues := make([]any, len(records))
+		for row, r := range records {
+			if col < len(r) {
+				colValues[row] = r[col]
+			}
+		}
+		values[col] = inferColumn(colValues, parsers)
+
+		name := fmt.Sprintf("Column %d", col+1)
+		if col < len(headers) && headers[col] != "" {
+			name = headers[col]
+		}
+		if isNumericColumn(values[col]) {
+			columns[col] = NewTableColumnRight(name)
+		} else {
+			columns[col] = NewTableColumnLeft(name)
+		}
+	}
+
+	t := NewTable(columns...)
+	for row := range records {
+		r := make([]any, numCols)
+		for col := range values {
+			r[col] = values[col][row]
+		}
+		t.AddRow(r...)
+	}
+	return t
+}
+
+// NewTableFromCSV reads CSV data from `r` and creates a table from it.
+// If `header` is true, the first record is used as column names,
+// otherwise the columns are named "Column 1", "Column 2", etc.
+// Records may have different numbers of fields.
+//
+// Column types are inferred (ints, floats, bools, durations, times or strings),
+// so values are highlighted according to their type. Numeric columns are right-aligned.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TablePadChar`
+func NewTableFromCSV(r io.Reader, separator rune, header bool) (*Table, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = separator
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := []string{}
+	if header && len(records) > 0 {
+		headers, records = records[0], records[1:]
+	}
+	rows := [][]any{}
+	for _, rec := range records {
+		row := []any{}
+		for _, v := range rec {
+			row = append(row, v)
+		}
+		rows = append(rows, row)
+	}
+	return newTableFromRecords(headers, rows, tableValueParsers), nil
+}
+
+// NewTableFromTSV reads tab separated values from `r` and creates a table from it, see NewTableFromCSV.
+func NewTableFromTSV(r io.Reader, header bool) (*Table, error) {
+	return NewTableFromCSV(r, '\t', header)
+}
+
+// jsonValue converts JSON numbers to ints if they are integers that fit into an int, otherwise to float64.
+func jsonValue(v any) any {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := strconv.Atoi(n.String()); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// decodeJSONObject decodes a JSON object and returns its keys in the order of the document.
+func decodeJSONObject(data []byte) ([]string, map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if _, err := dec.Token(); err != nil { // opening brace
+		return nil, nil, err
+	}
+	keys := []string{}
+	values := map[string]any{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := tok.(string) // object keys are always strings
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = jsonValue(v)
+	}
+	return keys, values, nil
+}
+
+// NewTableFromJSON reads a JSON array from `r` and creates a table from it.
+//
+// The array can either contain objects (the keys become the columns, in order of their first appearance)
+// or arrays (the first array contains the column names, like the output of Table.JSON).
+//
+// Numbers, bools and strings keep their decoded types, columns of strings are converted
+// to durations or times if all of their values can be parsed as such.
+// Values are highlighted according to their type. Numeric columns are right-aligned.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TablePadChar`
+func NewTableFromJSON(r io.Reader) (*Table, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if data = bytes.TrimSpace(data); len(data) == 0 || data[0] != '[' {
+		return nil, fmt.Errorf("expected a JSON array")
+	}
+	items := []json.RawMessage{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	headers := []string{}
+	rows := [][]any{}
+	objects := []map[string]any{}
+	seen := map[string]bool{}
+	isObjects := false
+	for i, item := range items {
+		item = bytes.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		switch item[0] {
+		case '{':
+			if i > 0 && !isObjects {
+				return nil, fmt.Errorf("element %d: can't mix objects and arrays", i)
+			}
+			isObjects = true
+			keys, obj, err := decodeJSONObject(item)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			for _, k := range keys {
+				if !seen[k] {
+					seen[k] = true
+					headers = append(headers, k)
+				}
+			}
+			objects = append(objects, obj)
+		case '[':
+			if isObjects {
+				return nil, fmt.Errorf("element %d: can't mix objects and arrays", i)
+			}
+			dec := json.NewDecoder(bytes.NewReader(item))
+			dec.UseNumber()
+			row := []any{}
+			if err := dec.Decode(&row); err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			if i == 0 {
+				for _, h := range row {
+					headers = append(headers, fmt.Sprint(jsonValue(h)))
+				}
+				continue
+			}
+			for j := range row {
+				row[j] = jsonValue(row[j])
+			}
+			rows = append(rows, row)
+		default:
+			return nil, fmt.Errorf("element %d: expected an object or array", i)
+		}
+	}
+
+	for _, obj := range objects {
+		row := make([]any, len(headers))
+		for j, h := range headers {
+			row[j] = obj[h]
+		}
+		rows = append(rows, row)
+	}
+	return newTableFromRecords(headers, rows, tableTextParsers), nil
+}
+
+// NewTableFromYAML reads a YAML sequence from `r` and creates a table from it.
+//
+// The sequence can either contain mappings (the keys become the columns, in order of their first appearance)
+// or sequences (the first sequence contains the column names, like the output of Table.YAML).
+//
+// Numbers, bools and strings keep their decoded types, columns of strings are converted
+// to durations or times if all of their values can be parsed as such.
+// Values are highlighted according to their type. Numeric columns are right-aligned.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TablePadChar`
+func NewTableFromYAML(r io.Reader) (*Table, error) {
+	doc := &yaml.Node{}
+	if err := yaml.NewDecoder(r).Decode(doc); err != nil {
+		if err == io.EOF {
+			return newTableFromRecords(nil, nil, tableTextParsers), nil // empty document
+		}
+		return nil, err
+	}
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("line %d: expected a sequence", root.Line)
+	}
+
+	value := func(n *yaml.Node) (any, error) {
+		if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!str" {
+			return n.Value, nil // durations and times are inferred with the rest of the column
+		}
+		var v any
+		err := n.Decode(&v)
+		return v, err
+	}
+
+	headers := []string{}
+	rows := [][]any{}
+	objects := []map[string]any{}
+	seen := map[string]bool{}
+	isMappings := false
+	for i, item := range root.Content {
+		switch item.Kind {
+		case yaml.MappingNode:
+			if i > 0 && !isMappings {
+				return nil, fmt.Errorf("line %d: can't mix mappings and sequences", item.Line)
+			}
+			isMappings = true
+			obj := map[string]any{}
+			for j := 0; j+1 < len(item.Content); j += 2 {
+				k := item.Content[j].Value
+				v, err := value(item.Content[j+1])
+				if err != nil {
+					return nil, err
+				}
+				if !seen[k] {
+					seen[k] = true
+					headers = append(headers, k)
+				}
+				obj[k] = v
+			}
+			objects = append(objects, obj)
+		case yaml.SequenceNode:
+			if isMappings {
+				return nil, fmt.Errorf("line %d: can't mix mappings and sequences", item.Line)
+			}
+			row := []any{}
+			for _, n := range item.Content {
+				v, err := value(n)
+				if err != nil {
+					return nil, err
+				}
+				row = append(row, v)
+			}
+			if i == 0 {
+				for _, h := range row {
+					headers = append(headers, fmt.Sprint(h))
+				}
+				continue
+			}
+			rows = append(rows, row)
+		default:
+			return nil, fmt.Errorf("line %d: expected a mapping or sequence", item.Line)
+		}
+	}
+
+	for _, obj := range objects {
+		row := make([]any, len(headers))
+		for j, h := range headers {
+			row[j] = obj[h]
+		}
+		rows = append(rows, row)
+	}
+	return newTableFromRecords(headers, rows, tableTextParsers), nil
+}
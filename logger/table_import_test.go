@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInferColumn(t *testing.T) {
+	date := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		values   []any
+		expected []any
+	}{
+		{
+			name:     "ints",
+			values:   []any{"1", " 2 ", "-3"},
+			expected: []any{1, 2, -3},
+		},
+		{
+			name:     "zero-padded ints stay strings",
+			values:   []any{"007", "42"},
+			expected: []any{"007", "42"},
+		},
+		{
+			name:     "ints and floats become floats",
+			values:   []any{"1", "2.5", "0.5"},
+			expected: []any{1.0, 2.5, 0.5},
+		},
+		{
+			name:     "zero-padded floats stay strings",
+			values:   []any{"00.5", "1.5"},
+			expected: []any{"00.5", "1.5"},
+		},
+		{
+			name:     "infinity is no float",
+			values:   []any{"1.5", "Inf"},
+			expected: []any{"1.5", "Inf"},
+		},
+		{
+			name:     "bools",
+			values:   []any{"true", "FALSE"},
+			expected: []any{true, false},
+		},
+		{
+			name:     "durations",
+			values:   []any{"1m30s", "2h"},
+			expected: []any{90 * time.Second, 2 * time.Hour},
+		},
+		{
+			name:     "times",
+			values:   []any{"2024-05-06", "2024-05-06T00:00:00Z"},
+			expected: []any{date, date},
+		},
+		{
+			name:     "empty strings become nil",
+			values:   []any{"1", "", nil, "  "},
+			expected: []any{1, nil, nil, nil},
+		},
+		{
+			name:     "mixed types stay strings",
+			values:   []any{"1", "true"},
+			expected: []any{"1", "true"},
+		},
+		{
+			name:     "columns with other types are unchanged",
+			values:   []any{"1", 2, ""},
+			expected: []any{"1", 2, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		got := inferColumn(tt.values, tableValueParsers)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: inferColumn(%#v) = %#v, want %#v", tt.name, tt.values, got, tt.expected)
+		}
+	}
+}
+
+func TestNewTableFromCSV(t *testing.T) {
+	tbl, err := NewTableFromCSV(strings.NewReader("ID,Size,Name\n007,10,a\n042,2.5,b\n"), ',', true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]any{{"ID", "Size", "Name"}, {"007", 10.0, "a"}, {"042", 2.5, "b"}}
+	if got := tbl.RawData(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("RawData() = %#v, want %#v", got, expected)
+	}
+	padDirs := []int{PAD_RIGHT, PAD_LEFT, PAD_RIGHT}
+	for i, col := range tbl.series {
+		if col.padDir != padDirs[i] {
+			t.Errorf("column %s: padDir = %d, want %d", col.Name, col.padDir, padDirs[i])
+		}
+	}
+}
+
+func TestNewTableFromJSONKeepsTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected [][]any
+	}{
+		{
+			name: "objects",
+			data: `[{"id":"007","n":1,"f":1.5,"ok":true},{"id":"8","n":2,"f":2,"x":null}]`,
+			expected: [][]any{
+				{"id", "n", "f", "ok", "x"},
+				{"007", 1, 1.5, true, nil},
+				{"8", 2, 2, nil, nil},
+			},
+		},
+		{
+			name: "quoted numbers and bools stay strings",
+			data: `[["a","b"],["1","true"],["2","false"]]`,
+			expected: [][]any{
+				{"a", "b"},
+				{"1", "true"},
+				{"2", "false"},
+			},
+		},
+		{
+			name: "strings become durations and times",
+			data: `[["d","t"],["1m30s","2024-05-06T00:00:00Z"]]`,
+			expected: [][]any{
+				{"d", "t"},
+				{90 * time.Second, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tbl, err := NewTableFromJSON(strings.NewReader(tt.data))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := tbl.RawData(); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: RawData() = %#v, want %#v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestNewTableFromYAMLKeepsTypes(t *testing.T) {
+	data := `
+- id: "007"
+  n: 1
+  f: 1.5
+  ok: true
+  d: 1m30s
+- id: "8"
+  n: 2
+  f: 2.5
+  ok: false
+  d: 2h
+`
+	tbl, err := NewTableFromYAML(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]any{
+		{"id", "n", "f", "ok", "d"},
+		{"007", 1, 1.5, true, 90 * time.Second},
+		{"8", 2, 2.5, false, 2 * time.Hour},
+	}
+	if got := tbl.RawData(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("RawData() = %#v, want %#v", got, expected)
+	}
+}
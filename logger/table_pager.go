@@ -0,0 +1,448 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/colorizers"
+	"github.com/toxyl/glog/terminal"
+	"github.com/toxyl/glog/utils"
+)
+
+// keys of the pager, escape sequences are normalized to these names
+const (
+	pagerKeyUp       = "up"
+	pagerKeyDown     = "down"
+	pagerKeyPageUp   = "pgup"
+	pagerKeyPageDown = "pgdn"
+	pagerKeyHome     = "home"
+	pagerKeyEnd      = "end"
+	pagerKeyEsc      = "esc"
+	pagerKeyEnter    = "enter"
+	pagerKeyBack     = "backspace"
+	pagerKeyCtrlC    = "ctrl+c"
+)
+
+var pagerSequences = map[string]string{
+	"\033[A":  pagerKeyUp,
+	"\033OA":  pagerKeyUp,
+	"\033[B":  pagerKeyDown,
+	"\033OB":  pagerKeyDown,
+	"\033[5~": pagerKeyPageUp,
+	"\033[6~": pagerKeyPageDown,
+	"\033[H":  pagerKeyHome,
+	"\033OH":  pagerKeyHome,
+	"\033[1~": pagerKeyHome,
+	"\033[7~": pagerKeyHome,
+	"\033[F":  pagerKeyEnd,
+	"\033OF":  pagerKeyEnd,
+	"\033[4~": pagerKeyEnd,
+	"\033[8~": pagerKeyEnd,
+	"\033":    pagerKeyEsc,
+	"\r":      pagerKeyEnter,
+	"\n":      pagerKeyEnter,
+	"\177":    pagerKeyBack,
+	"\b":      pagerKeyBack,
+	"\003":    pagerKeyCtrlC,
+}
+
+// parsePagerKeys splits raw terminal input into keys. Known escape sequences and control
+// characters are returned by name (e.g. "up"), everything else as the typed character.
+// Unknown escape sequences, including keys pressed with Alt (e.g. "\033x"), are dropped,
+// only an ESC that isn't followed by anything is the escape key.
+func parsePagerKeys(data []byte) []string {
+	keys := []string{}
+	for i := 0; i < len(data); {
+		n := 1
+		switch {
+		case data[i] == '\033' && i+2 < len(data) && data[i+1] == 'O':
+			n = 3
+		case data[i] == '\033' && i+1 < len(data) && data[i+1] == '[':
+			n = 2
+			for i+n < len(data) && data[i+n] >= 0x30 && data[i+n] <= 0x3F {
+				n++
+			}
+			if i+n < len(data) {
+				n++ // final byte
+			}
+		case data[i] == '\033' && i+1 < len(data): // Alt+key
+			_, size := utf8.DecodeRune(data[i+1:])
+			n = 1 + size
+		case data[i] >= utf8.RuneSelf:
+			_, n = utf8.DecodeRune(data[i:])
+		}
+		seq := string(data[i : i+n])
+		i += n
+		if key, ok := pagerSequences[seq]; ok {
+			keys = append(keys, key)
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(seq); seq[0] != '\033' && unicode.IsPrint(r) {
+			keys = append(keys, seq)
+		}
+	}
+	return keys
+}
+
+// highlightMatches renders all case-insensitive occurrences of `query` in `line` reversed.
+// Returns the line and whether it contains a match.
+func highlightMatches(line, query string) (string, bool) {
+	if query == "" {
+		return line, false
+	}
+	spans := ansi.Parse(line)
+	plain := ""
+	for _, sp := range spans {
+		plain += sp.Text
+	}
+	marked := make([]bool, len(plain))
+	found := false
+	for i := 0; i+len(query) <= len(plain); {
+		if strings.EqualFold(plain[i:i+len(query)], query) {
+			for j := i; j < i+len(query); j++ {
+				marked[j] = true
+			}
+			found = true
+			i += len(query)
+			continue
+		}
+		_, n := utf8.DecodeRuneInString(plain[i:])
+		i += n
+	}
+	if !found {
+		return line, false
+	}
+
+	res := []ansi.Span{}
+	pos := 0
+	for _, sp := range spans {
+		for start := 0; start < len(sp.Text); {
+			end := start
+			for end < len(sp.Text) && marked[pos+end] == marked[pos+start] {
+				end++
+			}
+			part := ansi.Span{Text: sp.Text[start:end], Style: sp.Style}
+			part.Style.Reverse = part.Style.Reverse != marked[pos+start]
+			res = append(res, part)
+			start = end
+		}
+		pos += len(sp.Text)
+	}
+	return ansi.Render(res), true
+}
+
+// tablePager holds the state of an interactive pager session, see Table.Page.
+type tablePager struct {
+	table    *Table
+	width    int      // terminal width the lines have been rendered for
+	header   []string // sticky lines at the top
+	body     []string // scrollable lines (rows, footer and bottom border)
+	numRows  int      // number of rows matching the filter
+	offset   int      // first visible body line
+	sortCol  int      // -1 if not sorted
+	sortDesc bool
+	filter   string
+	search   string
+	prompt   string // "/" (search) or "f" (filter) while editing, empty otherwise
+	input    string // text entered at the prompt
+	previous string // search before the prompt was opened, restored when it's cancelled
+}
+
+// matchesFilter returns true if the given row matches the filter, which is either
+// a text that must be part of any value or "<column>:<text>" to only check one column.
+func (p *tablePager) matchesFilter(row int) bool {
+	if p.filter == "" {
+		return true
+	}
+	text := p.filter
+	cols := p.table.series
+	if name, value, ok := strings.Cut(p.filter, ":"); ok {
+		for _, col := range p.table.series {
+			if strings.EqualFold(col.Name, strings.TrimSpace(name)) {
+				cols = []*TableColumn{col}
+				text = value
+				break
+			}
+		}
+	}
+	text = strings.ToLower(text)
+	for _, col := range cols {
+		if row < len(col.values) && strings.Contains(strings.ToLower(utils.StripANSI(col.values[row])), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// view returns a copy of the table with the filter and sort order applied.
+// Separator rows are removed while a filter is active.
+func (p *tablePager) view() *Table {
+	numRows := p.table.numRows()
+	rows := []int{}
+	for row := 0; row < numRows; row++ {
+		if p.filter != "" && p.table.isSeparatorRow(row) {
+			continue
+		}
+		if p.matchesFilter(row) {
+			rows = append(rows, row)
+		}
+	}
+	p.numRows = len(rows)
+
+	v := *p.table
+	v.series = make([]*TableColumn, len(p.table.series))
+	for i, col := range p.table.series {
+		c := *col
+		c.values = make([]string, 0, len(rows))
+		c.valuesRaw = make([]any, 0, len(rows))
+		c.maxLen = linesWidth(c.Name)
+		for _, row := range rows {
+			vs, raw := colorizers.Auto(TABLE_MISSING_VALUE), any(nil) // the column has less rows than the table
+			if row < len(col.values) {
+				vs, raw = col.values[row], col.valuesRaw[row]
+			}
+			c.values = append(c.values, vs)
+			c.valuesRaw = append(c.valuesRaw, raw)
+			c.maxLen = max(c.maxLen, linesWidth(vs))
+		}
+		v.series[i] = &c
+	}
+	if p.sortCol >= 0 {
+		v.SortByKeys(TableSortKey{Column: v.series[p.sortCol].Name, Desc: p.sortDesc})
+	}
+	return &v
+}
+
+// update renders the view for the given terminal width.
+func (p *tablePager) update(width int) {
+	v := p.view()
+	border := getBorderSet(v.borderStyle)
+	widths := v.columnWidths(width, v.footer())
+	p.header = v.headerLines(border, widths, true)
+	p.body = v.render(width, true)[len(p.header):]
+	p.width = width
+}
+
+// visibleLines returns the number of body lines that fit on the screen.
+func (p *tablePager) visibleLines(height int) int {
+	return max(height-len(p.header)-1, 1) // 1 line for the status
+}
+
+// scroll moves the visible part of the body by `n` lines.
+func (p *tablePager) scroll(n, height int) {
+	p.offset = max(min(p.offset+n, len(p.body)-p.visibleLines(height)), 0)
+}
+
+// findMatch scrolls to the next (`dir` > 0) or previous (`dir` < 0) line matching the search,
+// starting at line `from` and wrapping around at the ends.
+func (p *tablePager) findMatch(from, dir, height int) {
+	n := len(p.body)
+	for i := 0; i < n; i++ {
+		ln := ((from+i*dir)%n + n) % n
+		if _, ok := highlightMatches(p.body[ln], p.search); ok {
+			p.offset = 0
+			p.scroll(ln, height)
+			return
+		}
+	}
+}
+
+// status returns the status line shown at the bottom of the screen.
+func (p *tablePager) status(height int) string {
+	switch p.prompt {
+	case "/":
+		return "/" + p.input
+	case "f":
+		return "filter: " + p.input
+	}
+	last := min(p.offset+p.visibleLines(height), len(p.body))
+	parts := []string{fmt.Sprintf("lines %d-%d/%d, %d rows", min(p.offset+1, last), last, len(p.body), p.numRows)}
+	if p.sortCol >= 0 {
+		dir := "▲"
+		if p.sortDesc {
+			dir = "▼"
+		}
+		parts = append(parts, fmt.Sprintf("sort: %s %s", p.table.series[p.sortCol].Name, dir))
+	}
+	if p.filter != "" {
+		parts = append(parts, "filter: "+p.filter)
+	}
+	if p.search != "" {
+		parts = append(parts, "search: "+p.search)
+	}
+	parts = append(parts, "q quit, / search, n/N next/prev, f filter, 1-9 sort")
+	return strings.Join(parts, " | ")
+}
+
+// draw returns the output that redraws the whole screen.
+func (p *tablePager) draw(width, height int) string {
+	if width != p.width {
+		p.update(width)
+	}
+	p.scroll(0, height) // keep the offset valid if the terminal has been resized
+
+	var sb strings.Builder
+	sb.WriteString(ansi.CursorPosition(1, 1).String())
+	for _, line := range p.header {
+		sb.WriteString(line + ansi.ClearToEOL().String() + "\n")
+	}
+	for i := 0; i < p.visibleLines(height); i++ {
+		line := ""
+		if ln := p.offset + i; ln < len(p.body) {
+			line, _ = highlightMatches(p.body[ln], p.search)
+		}
+		sb.WriteString(line + ansi.ClearToEOL().String() + "\n")
+	}
+	sb.WriteString(ansi.Reverse().Apply(utils.Truncate(p.status(height), width, TABLE_ELLIPSIS)))
+	sb.WriteString(ansi.ClearScreenFromCursor().String())
+	return sb.String()
+}
+
+// handlePrompt processes a key while the search or filter prompt is open.
+func (p *tablePager) handlePrompt(key string, height int) {
+	switch key {
+	case pagerKeyEnter:
+		if p.prompt == "f" {
+			p.filter = p.input
+			p.offset = 0
+			p.update(p.width)
+		}
+		p.prompt = ""
+		return
+	case pagerKeyEsc, pagerKeyCtrlC:
+		if p.prompt == "/" {
+			p.search = p.previous
+		}
+		p.prompt = ""
+		return
+	case pagerKeyBack:
+		if p.input != "" {
+			_, n := utf8.DecodeLastRuneInString(p.input)
+			p.input = p.input[:len(p.input)-n]
+		}
+	default:
+		if utf8.RuneCountInString(key) != 1 {
+			return // named key
+		}
+		p.input += key
+	}
+	if p.prompt == "/" { // incremental search
+		p.search = p.input
+		if p.search != "" {
+			p.findMatch(p.offset, 1, height)
+		}
+	}
+}
+
+// handle processes a key, returns false if the pager should quit.
+func (p *tablePager) handle(key string, height int) bool {
+	if p.prompt != "" {
+		p.handlePrompt(key, height)
+		return true
+	}
+	page := p.visibleLines(height)
+	switch key {
+	case "q", pagerKeyEsc, pagerKeyCtrlC:
+		return false
+	case "j", pagerKeyDown, pagerKeyEnter:
+		p.scroll(1, height)
+	case "k", pagerKeyUp:
+		p.scroll(-1, height)
+	case " ", pagerKeyPageDown:
+		p.scroll(page, height)
+	case "b", pagerKeyPageUp:
+		p.scroll(-page, height)
+	case "g", pagerKeyHome:
+		p.offset = 0
+	case "G", pagerKeyEnd:
+		p.scroll(len(p.body), height)
+	case "/", "f":
+		p.prompt = key
+		p.input = ""
+		p.previous = p.search
+		if key == "f" {
+			p.input = p.filter
+		}
+	case "n":
+		p.findMatch(p.offset+1, 1, height)
+	case "N":
+		p.findMatch(p.offset-1, -1, height)
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		col := int(key[0] - '1')
+		if col >= len(p.table.series) {
+			break
+		}
+		// unsorted -> ascending -> descending -> unsorted
+		switch {
+		case p.sortCol != col:
+			p.sortCol, p.sortDesc = col, false
+		case !p.sortDesc:
+			p.sortDesc = true
+		default:
+			p.sortCol = -1
+		}
+		p.update(p.width)
+	}
+	return true
+}
+
+// Page shows the table in an interactive full-screen pager. The header stays at the top
+// while the rows can be scrolled and the table is rendered with the width of the terminal.
+//
+// Keys:
+//
+//   - j/k, arrow keys, Enter: scroll by one line
+//   - Space/b, PgDn/PgUp: scroll by one page
+//   - g/G, Home/End: jump to the start/end
+//   - /: incremental search, matches are highlighted, n/N jump to the next/previous match
+//   - f: filter rows by text, use "<column>:<text>" to only check one column, an empty filter shows all rows
+//   - 1-9: sort by the n-th column (ascending, descending, original order)
+//   - q, Esc: quit
+//
+// If stdin or stdout is not a terminal or the table has no columns, the table is printed with `logger` instead (see Print).
+// The table itself is not changed by filtering or sorting.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TableBorderStyle`
+func (t *Table) Page(logger *Logger) error {
+	in, out := os.Stdin, os.Stdout
+	if len(t.series) == 0 || !terminal.IsTerminal(in.Fd()) || !terminal.IsTerminal(out.Fd()) {
+		t.Print(logger)
+		return nil
+	}
+	state, err := terminal.MakeRaw(in.Fd())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = terminal.Restore(in.Fd(), state) }()
+	_, _ = ansi.EnterAlternateScreen().Combine(ansi.HideCursor(), ansi.DisableLineWrap()).Write(out)
+	defer func() { _, _ = ansi.EnableLineWrap().Combine(ansi.ShowCursor(), ansi.ExitAlternateScreen()).Write(out) }()
+
+	p := &tablePager{table: t, sortCol: -1}
+	buf := make([]byte, 256)
+	for {
+		// the size is queried on every redraw, so resizing the terminal takes effect with the next key
+		width, height := terminal.Width(), terminal.Height()
+		if width <= 0 || height <= 0 {
+			width, height = 80, 24
+		}
+		if _, err := io.WriteString(out, p.draw(width, height)); err != nil {
+			return err
+		}
+		n, err := in.Read(buf)
+		if err != nil {
+			return err
+		}
+		for _, key := range parsePagerKeys(buf[:n]) {
+			if !p.handle(key, height) {
+				return nil
+			}
+		}
+	}
+}
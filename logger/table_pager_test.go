@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/toxyl/glog/ansi"
+)
+
+func TestParsePagerKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+	}{
+		{name: "characters", data: "jk/ü", expected: []string{"j", "k", "/", "ü"}},
+		{name: "arrow keys", data: "\033[A\033OB", expected: []string{pagerKeyUp, pagerKeyDown}},
+		{name: "page keys", data: "\033[5~\033[6~", expected: []string{pagerKeyPageUp, pagerKeyPageDown}},
+		{name: "home and end", data: "\033[H\033[1~\033OF\033[8~", expected: []string{pagerKeyHome, pagerKeyHome, pagerKeyEnd, pagerKeyEnd}},
+		{name: "control characters", data: "\r\n\177\b\003", expected: []string{pagerKeyEnter, pagerKeyEnter, pagerKeyBack, pagerKeyBack, pagerKeyCtrlC}},
+		{name: "escape", data: "\033", expected: []string{pagerKeyEsc}},
+		{name: "alt+key is dropped", data: "a\033xb", expected: []string{"a", "b"}},
+		{name: "alt+multi-byte key is dropped", data: "\033üa", expected: []string{"a"}},
+		{name: "alt+O and alt+[ are dropped", data: "\033O", expected: []string{}},
+		{name: "unknown sequences are dropped", data: "\033[1;5Aq", expected: []string{"q"}},
+		{name: "incomplete sequences are dropped", data: "\033[", expected: []string{}},
+		{name: "unprintable characters are dropped", data: "\t\001x", expected: []string{"x"}},
+	}
+
+	for _, tt := range tests {
+		if got := parsePagerKeys([]byte(tt.data)); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: parsePagerKeys(%q) = %q, want %q", tt.name, tt.data, got, tt.expected)
+		}
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	red := ansi.Wrap("Web", 1).String()
+
+	tests := []struct {
+		name     string
+		line     string
+		query    string
+		found    bool
+		expected []string // texts rendered reversed
+	}{
+		{name: "empty query", line: "web", query: "", found: false, expected: []string{}},
+		{name: "no match", line: "web", query: "db", found: false, expected: []string{}},
+		{name: "case-insensitive", line: "Web and WEB", query: "web", found: true, expected: []string{"Web", "WEB"}},
+		{name: "across styled parts", line: "my" + red + "site", query: "yweBs", found: true, expected: []string{"y", "Web", "s"}},
+		{name: "after multi-byte characters", line: "über web", query: "WEB", found: true, expected: []string{"web"}},
+	}
+
+	for _, tt := range tests {
+		got, found := highlightMatches(tt.line, tt.query)
+		if found != tt.found {
+			t.Errorf("%s: highlightMatches(%q, %q) found = %v, want %v", tt.name, tt.line, tt.query, found, tt.found)
+		}
+		reversed := []string{}
+		plain := ""
+		for _, sp := range ansi.Parse(got) {
+			plain += sp.Text
+			if sp.Style.Reverse {
+				reversed = append(reversed, sp.Text)
+			}
+		}
+		if !reflect.DeepEqual(reversed, tt.expected) {
+			t.Errorf("%s: highlightMatches(%q, %q) reversed %q, want %q", tt.name, tt.line, tt.query, reversed, tt.expected)
+		}
+		if want := ansi.Strip(tt.line); plain != want {
+			t.Errorf("%s: highlightMatches(%q, %q) text = %q, want %q", tt.name, tt.line, tt.query, plain, want)
+		}
+	}
+}
+
+// newPagerTable returns a table with a separator and a column with less rows than the table.
+func newPagerTable() *Table {
+	tbl := NewTable(NewTableColumnLeft("Host"), NewTableColumnRight("CPU"), NewTableColumnLeft("Note"))
+	tbl.series[0].Push("web", "db", "---", "cache")
+	tbl.series[1].Push(30, 10, "---", 20)
+	tbl.series[2].Push("web server")
+	return tbl
+}
+
+func TestPagerFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		numRows  int // separators are only kept without filter
+		expected [][]any
+	}{
+		{
+			name:     "no filter",
+			filter:   "",
+			numRows:  4,
+			expected: [][]any{{"Host", "CPU", "Note"}, {"web", 30, "web server"}, {"db", 10, nil}, {"cache", 20, nil}},
+		},
+		{
+			name:     "any column, case-insensitive",
+			filter:   "WEB",
+			numRows:  1,
+			expected: [][]any{{"Host", "CPU", "Note"}, {"web", 30, "web server"}},
+		},
+		{
+			name:     "one column",
+			filter:   "cpu:0",
+			numRows:  3,
+			expected: [][]any{{"Host", "CPU", "Note"}, {"web", 30, "web server"}, {"db", 10, nil}, {"cache", 20, nil}},
+		},
+		{
+			name:     "one column without match in others",
+			filter:   "note:server",
+			numRows:  1,
+			expected: [][]any{{"Host", "CPU", "Note"}, {"web", 30, "web server"}},
+		},
+		{
+			name:     "unknown column is part of the text",
+			filter:   "x:web",
+			numRows:  0,
+			expected: [][]any{{"Host", "CPU", "Note"}},
+		},
+	}
+
+	for _, tt := range tests {
+		p := &tablePager{table: newPagerTable(), sortCol: -1, filter: tt.filter}
+		v := p.view()
+		if got := v.RawData(); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: filter %q = %#v, want %#v", tt.name, tt.filter, got, tt.expected)
+		}
+		if p.numRows != tt.numRows {
+			t.Errorf("%s: numRows = %d, want %d", tt.name, p.numRows, tt.numRows)
+		}
+	}
+}
+
+func TestPagerSort(t *testing.T) {
+	p := &tablePager{table: newPagerTable(), sortCol: -1}
+	p.update(80)
+
+	// the same key cycles through ascending, descending and the original order
+	tests := []struct {
+		key      string
+		expected []any
+	}{
+		{key: "2", expected: []any{10, 30, "---", 20}},
+		{key: "2", expected: []any{30, 10, "---", 20}},
+		{key: "2", expected: []any{30, 10, "---", 20}},
+		{key: "1", expected: []any{10, 30, "---", 20}},
+		{key: "9", expected: []any{10, 30, "---", 20}}, // there is no 9th column
+	}
+
+	for i, tt := range tests {
+		p.handle(tt.key, 24)
+		if got := columnValues(p.view(), 1); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("key %d (%q): CPU = %#v, want %#v", i+1, tt.key, got, tt.expected)
+		}
+	}
+}
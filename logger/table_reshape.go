@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// columnIndex returns the index of the column with the given name or -1 if there is none.
+func (t *Table) columnIndex(name string) int {
+	for i, col := range t.series {
+		if col.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// pushHighlighted appends a value that has already been highlighted, so it keeps
+// the highlighting of the table it was taken from.
+func (t *TableColumn) pushHighlighted(v any, str string) *TableColumn {
+	t.values = append(t.values, str)
+	t.valuesRaw = append(t.valuesRaw, v)
+	t.maxLen = max(t.maxLen, linesWidth(str))
+	return t
+}
+
+// newReshapedColumn creates a column for a reshaped table, numeric columns are right-aligned.
+func newReshapedColumn(name string, values []any, highlighter func(a ...any) string) *TableColumn {
+	padDir := PAD_RIGHT
+	if isNumericColumn(values) {
+		padDir = PAD_LEFT
+	}
+	return NewTableColumnCustom(name, padDir, config.LoggerConfig.TablePadChar, highlighter)
+}
+
+// Transpose returns a new table with rows and columns swapped: the first column contains
+// the names of this table's columns and every row of this table becomes a column.
+//
+// If `keyColumn` is the name of a column, its values are used as names of the new columns
+// (and it doesn't become a row), otherwise the new columns are named "Row 1", "Row 2", etc.
+// Duplicate names are numbered to keep them unique, e.g. "web", "web (2)", "web (3)".
+// Values keep the highlighting of the column they come from. Separators are omitted (see RawData).
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TablePadChar`
+func (t *Table) Transpose(keyColumn string) *Table {
+	data := t.RawData()
+	headers, rows := data[0], data[1:]
+	key := t.columnIndex(keyColumn)
+
+	first := NewTableColumnLeft("Column")
+	if key >= 0 {
+		first = NewTableColumnLeft(keyColumn)
+	}
+	columns := []*TableColumn{first}
+	used := map[string]bool{}
+	for i, row := range rows {
+		name := fmt.Sprintf("Row %d", i+1)
+		if key >= 0 {
+			name = utils.StripANSI(t.series[key].fnHighlight(row[key]))
+		}
+		for n, base := 2, name; used[name]; n++ {
+			name = fmt.Sprintf("%s (%d)", base, n)
+		}
+		used[name] = true
+		values := []any{}
+		for j, v := range row {
+			if j != key {
+				values = append(values, v)
+			}
+		}
+		columns = append(columns, newReshapedColumn(name, values, nil))
+	}
+
+	for j, h := range headers {
+		if j == key {
+			continue
+		}
+		first.Push(h)
+		for i, row := range rows {
+			columns[i+1].pushHighlighted(row[j], t.series[j].fnHighlight(row[j]))
+		}
+	}
+	return NewTable(columns...)
+}
+
+// Pivot returns a new table that summarizes the values of `valueColumn` by the values
+// of `rowColumn` (one row per distinct value) and `colColumn` (one column per distinct value).
+// Rows and columns are ordered by the first appearance of their value.
+// Each cell contains the result of `aggregate` for all values with the cell's row and column value,
+// e.g. AggregateSum (the default if `aggregate` is nil). Combinations without values stay empty.
+// Records without row or column value (including group headers) are ignored.
+//
+// The first column uses the highlighter of `rowColumn`, the other columns use the highlighter
+// of `valueColumn` if `aggregate` uses it (see TableAggregate.UsesColumnHighlighter),
+// otherwise the results are highlighted according to their type (e.g. AggregateCount).
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TablePadChar`
+func (t *Table) Pivot(rowColumn, colColumn, valueColumn string, aggregate TableAggregate) (*Table, error) {
+	rowIdx, colIdx, valIdx := t.columnIndex(rowColumn), t.columnIndex(colColumn), t.columnIndex(valueColumn)
+	for _, c := range []struct {
+		name string
+		idx  int
+	}{{rowColumn, rowIdx}, {colColumn, colIdx}, {valueColumn, valIdx}} {
+		if c.idx < 0 {
+			return nil, fmt.Errorf("unknown column %q", c.name)
+		}
+	}
+	if aggregate == nil {
+		aggregate = AggregateSum
+	}
+
+	rowKeys, colKeys := []string{}, []string{}
+	rowValues := map[string]any{}
+	colSeen := map[string]bool{}
+	cells := map[[2]string][]any{}
+	for _, row := range t.RawData()[1:] {
+		r, c, v := row[rowIdx], row[colIdx], row[valIdx]
+		if rank, _ := sortRank(r); rank == sortRankNil {
+			continue
+		}
+		if rank, _ := sortRank(c); rank == sortRankNil {
+			continue
+		}
+		rk := utils.StripANSI(t.series[rowIdx].fnHighlight(r))
+		ck := utils.StripANSI(t.series[colIdx].fnHighlight(c))
+		if _, ok := rowValues[rk]; !ok {
+			rowKeys = append(rowKeys, rk)
+			rowValues[rk] = r
+		}
+		if !colSeen[ck] {
+			colSeen[ck] = true
+			colKeys = append(colKeys, ck)
+		}
+		if rank, _ := sortRank(v); rank != sortRankNil {
+			cells[[2]string{rk, ck}] = append(cells[[2]string{rk, ck}], v)
+		}
+	}
+
+	highlighter := aggregateHighlighter(aggregate, t.series[valIdx].fnHighlight)
+	first := NewTableColumnCustom(rowColumn, PAD_RIGHT, config.LoggerConfig.TablePadChar, t.series[rowIdx].fnHighlight)
+	columns := []*TableColumn{first}
+	results := make([][]any, len(colKeys))
+	for i, ck := range colKeys {
+		results[i] = make([]any, len(rowKeys))
+		for j, rk := range rowKeys {
+			if values, ok := cells[[2]string{rk, ck}]; ok {
+				results[i][j] = aggregate.Aggregate(values)
+			}
+		}
+		columns = append(columns, newReshapedColumn(ck, results[i], highlighter))
+	}
+
+	for j, rk := range rowKeys {
+		first.Push(rowValues[rk])
+		for i, col := range columns[1:] {
+			if results[i][j] == nil {
+				col.pushHighlighted(nil, "") // no values for this combination
+				continue
+			}
+			col.Push(results[i][j])
+		}
+	}
+	return NewTable(columns...), nil
+}
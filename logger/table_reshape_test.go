@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/toxyl/glog/utils"
+)
+
+func TestTranspose(t *testing.T) {
+	tbl := NewTable(NewTableColumnLeft("Host"), NewTableColumnRight("CPU"), NewTableColumnRight("Mem")).
+		AddRow("web", 1, 2).
+		AddRow("db", 3, 4).
+		AddSeparator().
+		AddRow("web", 5, 6).
+		AddRow("web", 7, 8)
+
+	tests := []struct {
+		name     string
+		key      string
+		expected [][]any
+	}{
+		{
+			name: "with key column, duplicates are numbered",
+			key:  "Host",
+			expected: [][]any{
+				{"Host", "web", "db", "web (2)", "web (3)"},
+				{"CPU", 1, 3, 5, 7},
+				{"Mem", 2, 4, 6, 8},
+			},
+		},
+		{
+			name: "without key column",
+			key:  "",
+			expected: [][]any{
+				{"Column", "Row 1", "Row 2", "Row 3", "Row 4"},
+				{"Host", "web", "db", "web", "web"},
+				{"CPU", 1, 3, 5, 7},
+				{"Mem", 2, 4, 6, 8},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got := tbl.Transpose(tt.key).RawData()
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: Transpose(%q) = %#v, want %#v", tt.name, tt.key, got, tt.expected)
+		}
+	}
+}
+
+func TestTransposeNumbersDuplicatesUniquely(t *testing.T) {
+	tbl := NewTable(NewTableColumnLeft("Key"), NewTableColumnRight("Value")).
+		AddRow("a", 1).
+		AddRow("a (2)", 2).
+		AddRow("a", 3)
+
+	got := tbl.Transpose("Key").RawData()[0]
+	expected := []any{"Key", "a", "a (2)", "a (3)"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Transpose(Key) header = %#v, want %#v", got, expected)
+	}
+}
+
+func TestPivot(t *testing.T) {
+	bytes := func(a ...any) string { return fmt.Sprint(a...) + " B" }
+	tbl := NewTable(NewTableColumnLeft("Host"), NewTableColumnLeft("Day"), NewTableColumnRightCustom("Traffic", ' ', bytes)).
+		AddRow("web", "mon", 10).
+		AddRow("db", "mon", 5).
+		AddRow("web", "tue", 20).
+		AddRow("web", "mon", 1).
+		AddRow(nil, "tue", 99). // no row value
+		AddGroup("ignored")
+
+	tests := []struct {
+		name      string
+		aggregate TableAggregate
+		expected  [][]any
+		rendered  []string // rendered values of the "mon" column
+	}{
+		{
+			name:      "sum by default",
+			aggregate: nil,
+			expected:  [][]any{{"Host", "mon", "tue"}, {"web", int64(11), int64(20)}, {"db", int64(5), nil}},
+			rendered:  []string{"11 B", "5 B"},
+		},
+		{
+			name:      "counts don't use the column highlighter",
+			aggregate: AggregateCount,
+			expected:  [][]any{{"Host", "mon", "tue"}, {"web", 2, 1}, {"db", 1, nil}},
+			rendered:  []string{"2", "1"},
+		},
+		{
+			name:      "custom aggregate with column highlighter",
+			aggregate: TableAggregateFunc(func(values []any) any { return len(values) * 100 }),
+			expected:  [][]any{{"Host", "mon", "tue"}, {"web", 200, 100}, {"db", 100, nil}},
+			rendered:  []string{"200 B", "100 B"},
+		},
+	}
+
+	for _, tt := range tests {
+		p, err := tbl.Pivot("Host", "Day", "Traffic", tt.aggregate)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got := p.RawData(); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: Pivot() = %#v, want %#v", tt.name, got, tt.expected)
+		}
+		rendered := []string{}
+		for _, v := range p.series[1].values {
+			rendered = append(rendered, strings.TrimSpace(utils.StripANSI(v)))
+		}
+		if !reflect.DeepEqual(rendered, tt.rendered) {
+			t.Errorf("%s: rendered values = %q, want %q", tt.name, rendered, tt.rendered)
+		}
+	}
+
+	if _, err := tbl.Pivot("Host", "Week", "Traffic", nil); err == nil || !strings.Contains(err.Error(), `"Week"`) {
+		t.Errorf("Pivot() with unknown column error = %v, want unknown column", err)
+	}
+}
@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/toxyl/glog/cast"
+	"github.com/toxyl/glog/utils"
+)
+
+// TableSortKey defines a column to sort a Table by and the sort direction.
+type TableSortKey struct {
+	Column string
+	Desc   bool
+}
+
+const (
+	sortRankNumber = iota
+	sortRankDuration
+	sortRankTime
+	sortRankBool
+	sortRankString
+	sortRankOther
+	sortRankNil
+)
+
+// sortRank determines the order of different types and returns the value normalized for comparison.
+func sortRank(v any) (int, any) {
+	if v == nil {
+		return sortRankNil, nil
+	}
+	if ok, d := cast.Duration(v); ok {
+		return sortRankDuration, d
+	}
+	if ok, i := cast.Int(v); ok {
+		return sortRankNumber, i
+	}
+	if ok, u := cast.Uint(v); ok {
+		return sortRankNumber, u
+	}
+	if ok, _, f := cast.Float(v); ok {
+		return sortRankNumber, f
+	}
+	if ok, t := cast.Time(v); ok {
+		return sortRankTime, t
+	}
+	if ok, b := cast.Bool(v); ok {
+		return sortRankBool, b
+	}
+	if ok, s := cast.String(v); ok {
+		s = strings.TrimSpace(utils.StripANSI(s))
+		if s == "" {
+			return sortRankNil, nil // empty cells sort like missing values
+		}
+		return sortRankString, s
+	}
+	return sortRankOther, fmt.Sprint(v)
+}
+
+func toFloat(v any) float64 {
+	switch n := v.(type) {
+	case int64:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
+
+// compareValues compares two raw table values.
+// Values of different types are ordered: numbers, durations, times, bools, strings, everything else.
+func compareValues(a, b any) int {
+	ra, va := sortRank(a)
+	rb, vb := sortRank(b)
+	if ra != rb {
+		return cmp.Compare(ra, rb)
+	}
+
+	switch x := va.(type) {
+	case nil:
+		return 0
+	case int64:
+		if y, ok := vb.(int64); ok {
+			return cmp.Compare(x, y)
+		}
+	case uint64:
+		if y, ok := vb.(uint64); ok {
+			return cmp.Compare(x, y)
+		}
+	case time.Duration:
+		return cmp.Compare(x, vb.(time.Duration))
+	case time.Time:
+		return x.Compare(vb.(time.Time))
+	case bool:
+		y := vb.(bool)
+		if x == y {
+			return 0
+		}
+		if !x {
+			return -1
+		}
+		return 1
+	case string:
+		y := vb.(string)
+		if c := strings.Compare(strings.ToLower(x), strings.ToLower(y)); c != 0 {
+			return c
+		}
+		return strings.Compare(x, y)
+	}
+
+	if ra == sortRankNumber {
+		return cmp.Compare(toFloat(va), toFloat(vb))
+	}
+	return 0
+}
+
+// SortBy sorts the rows of the table by the values of the given column.
+// See SortByKeys for details.
+func (t *Table) SortBy(column string, desc bool) *Table {
+	return t.SortByKeys(TableSortKey{Column: column, Desc: desc})
+}
+
+// SortByKeys sorts the rows of the table by the given keys, later keys are used
+// to break ties of earlier keys. Unknown columns are ignored.
+//
+// Values are compared by their raw type (ints, uints, floats, time.Time, time.Duration, bools, strings),
+// values of different types are ordered: numbers, durations, times, bools, strings, everything else.
+// Missing values (nil or empty strings) are always sorted last.
+//
+// Rows containing a separator ("---") and group headers (see AddGroup) stay in place
+// and divide the table into sections which are sorted independently.
+func (t *Table) SortByKeys(keys ...TableSortKey) *Table {
+	t.normalize()
+
+	type sortColumn struct {
+		values []any
+		desc   bool
+	}
+	cols := []sortColumn{}
+	for _, k := range keys {
+		for _, col := range t.series {
+			if col.Name == k.Column {
+				cols = append(cols, sortColumn{values: col.valuesRaw, desc: k.Desc})
+				break
+			}
+		}
+	}
+	if len(cols) == 0 {
+		return t
+	}
+
+	less := func(a, b int) bool {
+		for _, c := range cols {
+			va, vb := c.values[a], c.values[b]
+			// missing values go last, regardless of the direction
+			na, _ := sortRank(va)
+			nb, _ := sortRank(vb)
+			if (na == sortRankNil) != (nb == sortRankNil) {
+				return nb == sortRankNil
+			}
+			r := compareValues(va, vb)
+			if c.desc {
+				r = -r
+			}
+			if r != 0 {
+				return r < 0
+			}
+		}
+		return false
+	}
+
+	numRows := t.numRows()
+	order := make([]int, 0, numRows)
+	section := []int{}
+	flush := func() {
+		sort.SliceStable(section, func(i, j int) bool { return less(section[i], section[j]) })
+		order = append(order, section...)
+		section = []int{}
+	}
+	for row := 0; row < numRows; row++ {
+		if t.isSeparatorRow(row) || t.isGroupRow(row) {
+			flush()
+			order = append(order, row)
+			continue
+		}
+		section = append(section, row)
+	}
+	flush()
+
+	for _, col := range t.series {
+		values := make([]string, numRows)
+		valuesRaw := make([]any, numRows)
+		for i, row := range order {
+			values[i] = col.values[row]
+			valuesRaw[i] = col.valuesRaw[row]
+		}
+		col.values = values
+		col.valuesRaw = valuesRaw
+	}
+	return t
+}
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// columnValues returns the raw values of the column with the given index, spans are unwrapped.
+func columnValues(t *Table, col int) []any {
+	res := []any{}
+	for _, v := range t.series[col].valuesRaw {
+		res = append(res, rawValue(v))
+	}
+	return res
+}
+
+func TestCompareValues(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		a, b     any
+		expected int
+	}{
+		{a: 2, b: 2.5, expected: -1},
+		{a: uint8(3), b: -1, expected: 1},
+		{a: int64(7), b: 7, expected: 0},
+		{a: 10, b: "9", expected: -1},       // numbers before strings
+		{a: time.Second, b: 1, expected: 1}, // durations after numbers
+		{a: time.Minute, b: time.Second, expected: 1},
+		{a: now, b: now.Add(time.Hour), expected: -1},
+		{a: now, b: time.Second, expected: 1}, // times after durations
+		{a: false, b: true, expected: -1},
+		{a: true, b: "true", expected: -1},          // bools before strings
+		{a: "apple", b: "Banana", expected: -1},     // case-insensitive
+		{a: "A", b: "a", expected: -1},              // ties are broken case-sensitively
+		{a: "\033[1mb\033[0m", b: "a", expected: 1}, // ANSI escapes are ignored
+		{a: "x", b: nil, expected: -1},              // missing values last
+		{a: "", b: nil, expected: 0},                // empty strings are missing values
+		{a: "  ", b: "x", expected: 1},
+		{a: nil, b: nil, expected: 0},
+	}
+
+	for _, tt := range tests {
+		got := compareValues(tt.a, tt.b)
+		if got != tt.expected {
+			t.Errorf("compareValues(%#v, %#v) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestSortByKeys(t *testing.T) {
+	newTable := func() *Table {
+		return NewTable(NewTableColumnLeft("Name"), NewTableColumnRight("Size")).
+			AddRow("b", 20).
+			AddRow("a", nil).
+			AddRow("", 5).
+			AddRow("c", 20).
+			AddRow("A", 1)
+	}
+
+	tests := []struct {
+		name     string
+		keys     []TableSortKey
+		col      int
+		expected []any
+	}{
+		{
+			name:     "ascending, missing values last",
+			keys:     []TableSortKey{{Column: "Name"}},
+			col:      0,
+			expected: []any{"A", "a", "b", "c", ""},
+		},
+		{
+			name:     "descending, missing values still last",
+			keys:     []TableSortKey{{Column: "Name", Desc: true}},
+			col:      0,
+			expected: []any{"c", "b", "a", "A", ""},
+		},
+		{
+			name:     "numbers descending",
+			keys:     []TableSortKey{{Column: "Size", Desc: true}},
+			col:      1,
+			expected: []any{20, 20, 5, 1, nil},
+		},
+		{
+			name:     "second key breaks ties",
+			keys:     []TableSortKey{{Column: "Size", Desc: true}, {Column: "Name", Desc: true}},
+			col:      0,
+			expected: []any{"c", "b", "", "A", "a"},
+		},
+		{
+			name:     "unknown columns are ignored",
+			keys:     []TableSortKey{{Column: "Missing"}},
+			col:      0,
+			expected: []any{"b", "a", "", "c", "A"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := columnValues(newTable().SortByKeys(tt.keys...), tt.col)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: got %#v, want %#v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestSortBySections(t *testing.T) {
+	tbl := NewTable(NewTableColumnLeft("Host"), NewTableColumnRight("Load")).
+		AddRow("web-2", 3).
+		AddRow("web-1", 9).
+		AddSeparator().
+		AddRow("db-2", 1).
+		AddRow("db-1", 4).
+		AddGroup("cache").
+		AddRow("cache-1", 7).
+		AddRow("cache-2", 2)
+
+	got := columnValues(tbl.SortBy("Load", true), 0)
+	expected := []any{"web-1", "web-2", "---", "db-1", "db-2", "cache", "cache-1", "cache-2"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("SortBy(Load, desc) = %#v, want %#v", got, expected)
+	}
+
+	// the other columns are reordered with the sort column
+	got = columnValues(tbl, 1)
+	expected = []any{9, 3, "---", 4, 1, nil, 7, 2}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Load column = %#v, want %#v", got, expected)
+	}
+}
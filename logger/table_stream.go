@@ -0,0 +1,171 @@
+package logger
+
+import "sync"
+
+// TableStream prints the rows of a table as they arrive instead of waiting for all data.
+//
+// The header is printed as soon as the column widths are known: immediately
+// (based on the headers and the min widths of the columns) or after a sample of rows has been
+// collected. If a later row needs wider columns, the current block
+// is closed and the header is printed again with the new widths.
+//
+// Rows are not kept after they have been printed (except for the last one, so group headers
+// and collapsed values work), so footers (aggregates) aren't supported
+// and heatmaps only consider the rows printed together.
+type TableStream struct {
+	lock    *sync.Mutex
+	table   *Table
+	logger  *Logger
+	widths  []int
+	sample  int
+	started bool
+	closed  bool
+	kept    bool // the first row has been printed already and is only kept as context for the next rows
+	header  bool // the header has been printed and no row has been printed below it yet
+	dataRow int
+}
+
+// NewTableStream creates a stream that prints a table with the given columns through `logger`.
+// If `sampleSize` is 0, the header is printed immediately, otherwise the first `sampleSize` rows
+// are collected first, so the initial column widths fit the data.
+// The stream works on copies of the columns without their values, the given columns aren't modified.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.TableMaxWidth`
+//   - `LoggerConfig.TableBorderStyle`
+func NewTableStream(logger *Logger, sampleSize int, columns ...*TableColumn) *TableStream {
+	copies := make([]*TableColumn, len(columns))
+	for i, col := range columns {
+		c := *col
+		c.Reset()
+		copies[i] = &c
+	}
+	s := &TableStream{
+		lock:   &sync.Mutex{},
+		table:  NewTable(copies...),
+		logger: logger,
+		sample: sampleSize,
+	}
+	if sampleSize <= 0 {
+		s.flush(true)
+	}
+	return s
+}
+
+// Push adds a row to the stream, the n-th value goes into the n-th column (see Table.AddRow).
+// The row is printed immediately unless the sample is still being collected.
+func (s *TableStream) Push(values ...any) *TableStream {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return s
+	}
+	s.table.AddRow(values...)
+	s.flush(false)
+	return s
+}
+
+// Separator adds a separator row to the stream.
+func (s *TableStream) Separator() *TableStream {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return s
+	}
+	s.table.AddSeparator()
+	s.flush(false)
+	return s
+}
+
+// Group adds a group header row to the stream (see Table.AddGroup).
+func (s *TableStream) Group(title any) *TableStream {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return s
+	}
+	s.table.AddGroup(title)
+	s.flush(false)
+	return s
+}
+
+// Close prints all rows that are still collected for the sample and the bottom border.
+// Rows pushed after closing the stream are ignored.
+func (s *TableStream) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return
+	}
+	s.flush(true)
+	if s.started {
+		s.print(s.table.bottomLines(getBorderSet(s.table.borderStyle), s.widths, s.table.rowBoundaries(s.table.numRows()-1)))
+	}
+	s.closed = true
+}
+
+func (s *TableStream) print(lines []string) {
+	for _, line := range lines {
+		s.logger.writeBlock(line)
+	}
+}
+
+// flush prints the pending rows, if `force` is false and the sample is incomplete, nothing is printed.
+func (s *TableStream) flush(force bool) {
+	t := s.table
+	numRows := t.numRows()
+	if len(t.series) == 0 || (!s.started && !force && numRows < s.sample) {
+		return // without columns there is nothing to draw a frame around
+	}
+
+	border := getBorderSet(t.borderStyle)
+	widths := t.columnWidths(t.availableWidth(s.logger.prefixWidth('_')), nil)
+	grown := false
+	for i := 0; s.started && i < len(widths); i++ {
+		grown = grown || widths[i] > s.widths[i]
+	}
+	switch {
+	case !s.started:
+		s.print(t.headerLines(border, widths, true))
+		s.started = true
+		s.header = true
+	case grown:
+		s.print(t.bottomLines(border, s.widths, t.rowBoundaries(s.lastPrinted())))
+		if s.kept { // the new block starts with the header, not with the last printed row
+			for _, col := range t.series {
+				col.values, col.valuesRaw = col.values[1:], col.valuesRaw[1:]
+			}
+			s.kept = false
+		}
+		s.print(t.headerLines(border, widths, true))
+		s.header = true
+	default:
+		widths = s.widths
+	}
+	s.widths = widths
+
+	numRows = t.numRows()
+	heatLo, heatHi := t.heatmapRanges()
+	for row := s.lastPrinted() + 1; row < numRows; row++ {
+		if s.header && t.isSeparatorRow(row) {
+			continue // the header already separates the rows from the ones above
+		}
+		s.header = false
+		s.print(t.rowLines(border, widths, row, &s.dataRow, heatLo, heatHi))
+	}
+	if numRows > 0 {
+		for _, col := range t.series {
+			col.keepLastRow()
+		}
+		s.kept = true
+	}
+}
+
+// lastPrinted returns the index of the last row that has been printed already or -1 if there is none.
+func (s *TableStream) lastPrinted() int {
+	if s.kept {
+		return 0
+	}
+	return -1
+}
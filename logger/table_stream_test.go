@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// newStreamLogger returns a logger without prefix and the lines it has written.
+func newStreamLogger(t *testing.T) (*Logger, *[]string) {
+	withConfig(t, func(c *config.Config) {
+		c.ShowDateTime = false
+		c.ShowRuntimeHumanReadable = false
+		c.ShowRuntimeSeconds = false
+		c.ShowRuntimeMilliseconds = false
+		c.ShowSubsystem = false
+		c.ShowIndicator = false
+		c.TableMaxWidth = 0
+	})
+	lines := &[]string{}
+	l := NewLogger("test", 1, false, func(s string) {
+		*lines = append(*lines, strings.TrimSpace(utils.StripANSI(s)))
+	})
+	return l, lines
+}
+
+func TestTableStreamSeparators(t *testing.T) {
+	l, lines := newStreamLogger(t)
+	NewTableStream(l, 0, NewTableColumnLeft("A"), NewTableColumnLeft("Name")).
+		Separator().
+		Push(1, "x").
+		Separator().
+		Push(2, "y").
+		Close()
+
+	expected := []string{
+		"┌───┬──────┐",
+		"│ A │ Name │",
+		"├───┼──────┤",
+		"│ 1 │ x    │",
+		"├───┼──────┤",
+		"│ 2 │ y    │",
+		"└───┴──────┘",
+	}
+	if !reflect.DeepEqual(*lines, expected) {
+		t.Errorf("output = %q, want %q", *lines, expected)
+	}
+}
+
+func TestTableStreamGrows(t *testing.T) {
+	l, lines := newStreamLogger(t)
+	NewTableStream(l, 0, NewTableColumnLeft("A")).
+		Push(1).
+		Separator().
+		Push(123).
+		Close()
+
+	expected := []string{
+		"┌───┐",
+		"│ A │",
+		"├───┤",
+		"│ 1 │",
+		"├───┤",
+		"└───┘",
+		"┌─────┐",
+		"│ A   │",
+		"├─────┤",
+		"│ 123 │",
+		"└─────┘",
+	}
+	if !reflect.DeepEqual(*lines, expected) {
+		t.Errorf("output = %q, want %q", *lines, expected)
+	}
+}
+
+func TestTableStreamKeepsColumns(t *testing.T) {
+	l, _ := newStreamLogger(t)
+	col := NewTableColumnLeft("A").Push("a", "b")
+	NewTableStream(l, 0, col).Push("c").Close()
+
+	if got := col.Values(); !reflect.DeepEqual(got, []any{"a", "b"}) {
+		t.Errorf("column values = %#v, want the original values", got)
+	}
+}
@@ -0,0 +1,317 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// plainLines returns the lines without ANSI escape sequences.
+func plainLines(lines []string) []string {
+	if lines == nil {
+		return nil
+	}
+	res := []string{}
+	for _, line := range lines {
+		res = append(res, utils.StripANSI(line))
+	}
+	return res
+}
+
+// newShortColumnTable returns a table whose second column has less rows than the first.
+func newShortColumnTable() *Table {
+	tbl := NewTable(NewTableColumnLeft("Name"), NewTableColumnLeft("X"))
+	tbl.series[0].Push("a", "b")
+	tbl.series[1].Push("1")
+	return tbl
+}
+
+func TestColumnWidths(t *testing.T) {
+	withConfig(t, func(c *config.Config) { c.TableBorderStyle = BORDER_LIGHT })
+	newTable := func(name, desc *TableColumn) *Table {
+		return NewTable(name, desc).AddRow("web", "a very long description")
+	}
+
+	tests := []struct {
+		name     string
+		table    *Table
+		maxWidth int
+		expected []int
+	}{
+		{
+			name:     "natural widths without limit",
+			table:    newTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Description")),
+			maxWidth: 0,
+			expected: []int{4, 23},
+		},
+		{
+			name:     "table that fits isn't shrunk",
+			table:    newTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Description")),
+			maxWidth: 34,
+			expected: []int{4, 23},
+		},
+		{
+			name:     "shrunk to the header width first",
+			table:    newTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Description")),
+			maxWidth: 24,
+			expected: []int{4, 13},
+		},
+		{
+			name:     "shrunk below the header widths, widest first",
+			table:    newTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Description")),
+			maxWidth: 14,
+			expected: []int{3, 4},
+		},
+		{
+			name:     "min widths are kept even if the table overflows",
+			table:    newTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Description").SetMinWidth(15)),
+			maxWidth: 14,
+			expected: []int{1, 15},
+		},
+		{
+			name:     "max widths limit the natural width",
+			table:    newTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Description").SetMaxWidth(10)),
+			maxWidth: 0,
+			expected: []int{4, 10},
+		},
+		{
+			name: "higher shrink priority is shrunk first",
+			table: NewTable(NewTableColumnLeft("Name").SetShrinkPriority(1), NewTableColumnLeft("Desc")).
+				AddRow("a long name", "a long text"),
+			maxWidth: 20,
+			expected: []int{4, 9},
+		},
+		{
+			name:     "missing values are as wide as the placeholder",
+			table:    newShortColumnTable(),
+			maxWidth: 0,
+			expected: []int{4, 3},
+		},
+		{
+			name:     "missing values aren't shrunk below the placeholder",
+			table:    newShortColumnTable(),
+			maxWidth: 1,
+			expected: []int{1, 3},
+		},
+		{
+			name:     "no columns",
+			table:    NewTable(),
+			maxWidth: 10,
+			expected: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.table.columnWidths(tt.maxWidth, nil); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: columnWidths(%d) = %v, want %v", tt.name, tt.maxWidth, got, tt.expected)
+		}
+	}
+}
+
+func TestTableColumnFit(t *testing.T) {
+	tests := []struct {
+		name     string
+		column   *TableColumn
+		value    string
+		width    int
+		expected []string
+	}{
+		{
+			name:     "padded to the right",
+			column:   NewTableColumnCustom("c", PAD_RIGHT, ' ', nil),
+			value:    "ab",
+			width:    5,
+			expected: []string{"ab   "},
+		},
+		{
+			name:     "padded to the left",
+			column:   NewTableColumnCustom("c", PAD_LEFT, '.', nil),
+			value:    "ab",
+			width:    5,
+			expected: []string{"...ab"},
+		},
+		{
+			name:     "line breaks",
+			column:   NewTableColumnCustom("c", PAD_RIGHT, ' ', nil),
+			value:    "a\nbc",
+			width:    3,
+			expected: []string{"a  ", "bc "},
+		},
+		{
+			name:     "wrapped",
+			column:   NewTableColumnCustom("c", PAD_RIGHT, ' ', nil),
+			value:    "one two three",
+			width:    7,
+			expected: []string{"one two", "three  "},
+		},
+		{
+			name:     "truncated",
+			column:   NewTableColumnCustom("c", PAD_RIGHT, ' ', nil).SetOverflow(OVERFLOW_TRUNCATE),
+			value:    "one two three",
+			width:    7,
+			expected: []string{"one tw…"},
+		},
+		{
+			name:     "only lines that are too wide are truncated",
+			column:   NewTableColumnCustom("c", PAD_LEFT, ' ', nil).SetOverflow(OVERFLOW_TRUNCATE),
+			value:    "abc\nabcdef",
+			width:    4,
+			expected: []string{" abc", "abc…"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.column.fit(tt.value, tt.width); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: fit(%q, %d) = %q, want %q", tt.name, tt.value, tt.width, got, tt.expected)
+		}
+	}
+}
+
+func TestTableRender(t *testing.T) {
+	withConfig(t, func(c *config.Config) { c.TableBorderStyle = BORDER_LIGHT })
+
+	tests := []struct {
+		name     string
+		table    *Table
+		maxWidth int
+		expected []string
+	}{
+		{
+			name:     "no columns",
+			table:    NewTable(),
+			maxWidth: 0,
+			expected: nil,
+		},
+		{
+			name:     "no rows",
+			table:    NewTable(NewTableColumnLeft("Name")),
+			maxWidth: 0,
+			expected: []string{
+				"┌──────┐",
+				"│ Name │",
+				"├──────┤",
+				"└──────┘",
+			},
+		},
+		{
+			name:     "missing values",
+			table:    newShortColumnTable(),
+			maxWidth: 0,
+			expected: []string{
+				"┌──────┬─────┐",
+				"│ Name │ X   │",
+				"├──────┼─────┤",
+				"│ a    │ 1   │",
+				"│ b    │ N/A │",
+				"└──────┴─────┘",
+			},
+		},
+		{
+			name: "shrunk and truncated",
+			table: NewTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Description").SetOverflow(OVERFLOW_TRUNCATE)).
+				AddRow("web", "a very long description"),
+			maxWidth: 24,
+			expected: []string{
+				"┌──────┬───────────────┐",
+				"│ Name │ Description   │",
+				"├──────┼───────────────┤",
+				"│ web  │ a very long … │",
+				"└──────┴───────────────┘",
+			},
+		},
+		{
+			name: "shrunk and wrapped",
+			table: NewTable(NewTableColumnLeft("Name"), NewTableColumnLeft("Description")).
+				AddRow("web", "a very long description"),
+			maxWidth: 24,
+			expected: []string{
+				"┌──────┬───────────────┐",
+				"│ Name │ Description   │",
+				"├──────┼───────────────┤",
+				"│ web  │ a very long   │",
+				"│      │ description   │",
+				"└──────┴───────────────┘",
+			},
+		},
+		{
+			name:     "missing values with the min width",
+			table:    newShortColumnTable(),
+			maxWidth: 1,
+			expected: []string{
+				"┌───┬─────┐",
+				"│ N │ X   │",
+				"│ a │     │",
+				"│ m │     │",
+				"│ e │     │",
+				"├───┼─────┤",
+				"│ a │ 1   │",
+				"│ b │ N/A │",
+				"└───┴─────┘",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := plainLines(tt.table.render(tt.maxWidth, true)); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: render(%d) = %q, want %q", tt.name, tt.maxWidth, got, tt.expected)
+		}
+	}
+}
+
+func TestRowLinesVerticalAlign(t *testing.T) {
+	tests := []struct {
+		name     string
+		valign   int
+		expected []string
+	}{
+		{
+			name:   "top",
+			valign: VALIGN_TOP,
+			expected: []string{
+				"│ x   │ 1 │ N/ │",
+				"│     │ 2 │ A  │",
+				"│     │ 3 │    │",
+				"│     │ 4 │    │",
+			},
+		},
+		{
+			name:   "middle",
+			valign: VALIGN_MIDDLE,
+			expected: []string{
+				"│     │ 1 │    │",
+				"│ x   │ 2 │ N/ │",
+				"│     │ 3 │ A  │",
+				"│     │ 4 │    │",
+			},
+		},
+		{
+			name:   "bottom",
+			valign: VALIGN_BOTTOM,
+			expected: []string{
+				"│     │ 1 │    │",
+				"│     │ 2 │    │",
+				"│     │ 3 │ N/ │",
+				"│ x   │ 4 │ A  │",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		// the last column has no values, its placeholder is wrapped as the column is too narrow
+		tbl := NewTable(
+			NewTableColumnCustom("A", PAD_RIGHT, ' ', nil).SetVerticalAlign(tt.valign),
+			NewTableColumnCustom("B", PAD_RIGHT, ' ', nil),
+			NewTableColumnCustom("C", PAD_RIGHT, ' ', nil).SetVerticalAlign(tt.valign),
+		)
+		tbl.series[0].Push("x")
+		tbl.series[1].Push("1\n2\n3\n4")
+		dataRow := 0
+		heatLo, heatHi := tbl.heatmapRanges()
+		got := plainLines(tbl.rowLines(getBorderSet(BORDER_LIGHT), []int{3, 1, 2}, 0, &dataRow, heatLo, heatHi))
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: rowLines() = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
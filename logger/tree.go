@@ -0,0 +1,381 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/toxyl/glog/ansi"
+	"github.com/toxyl/glog/colorizers"
+	"github.com/toxyl/glog/config"
+	"github.com/toxyl/glog/utils"
+)
+
+// TreeNode is a node of a Tree. It's rendered as "Key: Value", or just the key or value if the other is nil.
+type TreeNode struct {
+	Key       any
+	Value     any
+	children  []*TreeNode
+	collapsed bool
+}
+
+// NewTreeNode creates a node with the given value.
+func NewTreeNode(value any) *TreeNode {
+	return &TreeNode{Value: value}
+}
+
+// Add adds a child with the given value and returns the child, so that it can be populated further.
+func (n *TreeNode) Add(value any) *TreeNode {
+	child := NewTreeNode(value)
+	n.children = append(n.children, child)
+	return child
+}
+
+// AddKeyValue adds a child that is rendered as "key: value" and returns the child.
+func (n *TreeNode) AddKeyValue(key, value any) *TreeNode {
+	child := &TreeNode{Key: key, Value: value}
+	n.children = append(n.children, child)
+	return child
+}
+
+// AddNodes adds the given nodes as children.
+func (n *TreeNode) AddNodes(nodes ...*TreeNode) *TreeNode {
+	n.children = append(n.children, nodes...)
+	return n
+}
+
+// Children returns the children of the node.
+func (n *TreeNode) Children() []*TreeNode {
+	return n.children
+}
+
+// SetCollapsed hides the children of the node, the node shows the number of hidden descendants instead.
+func (n *TreeNode) SetCollapsed(collapsed bool) *TreeNode {
+	n.collapsed = collapsed
+	return n
+}
+
+// descendants returns the number of nodes below `n`.
+func (n *TreeNode) descendants() int {
+	res := len(n.children)
+	for _, c := range n.children {
+		res += c.descendants()
+	}
+	return res
+}
+
+// label returns the highlighted text of the node.
+func (n *TreeNode) label() string {
+	switch {
+	case n.Key == nil:
+		return colorizers.Auto(n.Value)
+	case n.Value == nil:
+		return colorizers.Auto(n.Key)
+	}
+	return colorizers.Auto(n.Key) + ": " + colorizers.Auto(n.Value)
+}
+
+// Tree renders hierarchical data with connectors:
+//
+//	root
+//	├── child
+//	│   └── grandchild
+//	└── child
+type Tree struct {
+	root     *TreeNode
+	maxDepth int
+}
+
+// NewTree creates a tree whose root node has the given value.
+func NewTree(root any) *Tree {
+	return &Tree{root: NewTreeNode(root)}
+}
+
+// Root returns the root node of the tree, add nodes to it to populate the tree.
+func (t *Tree) Root() *TreeNode {
+	return t.root
+}
+
+// SetMaxDepth limits the number of levels shown below the root, nodes at the last level
+// show the number of hidden descendants instead of their children. Use 0 for no limit.
+func (t *Tree) SetMaxDepth(depth int) *Tree {
+	t.maxDepth = depth
+	return t
+}
+
+// Lines returns the rendered lines of the tree.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.ColorTreeLines`
+func (t *Tree) Lines() []string {
+	res := []string{}
+	t.lines(&res, t.root, "", "", 0)
+	return res
+}
+
+// lines renders `n` and its children, `first` is the prefix of the node's first line,
+// `rest` the prefix of all lines below it (continuation lines and children).
+func (t *Tree) lines(res *[]string, n *TreeNode, first, rest string, depth int) {
+	color := config.LoggerConfig.ColorTreeLines
+	label := n.label()
+	hidden := len(n.children) > 0 && (n.collapsed || (t.maxDepth > 0 && depth >= t.maxDepth))
+	if hidden {
+		label += " " + ansi.Wrap(fmt.Sprintf("[+%d]", n.descendants()), color).String()
+	}
+	for i, ln := range utils.SplitLines(label) {
+		prefix := first
+		if i > 0 {
+			prefix = rest
+			if len(n.children) > 0 && !hidden {
+				prefix += ansi.Wrap("│   ", color).String()
+			}
+		}
+		*res = append(*res, prefix+ln)
+	}
+	if hidden {
+		return
+	}
+	for i, c := range n.children {
+		connector, indent := "├── ", "│   "
+		if i == len(n.children)-1 {
+			connector, indent = "└── ", "    "
+		}
+		t.lines(res, c, rest+ansi.Wrap(connector, color).String(), rest+ansi.Wrap(indent, color).String(), depth+1)
+	}
+}
+
+// Print prints the tree with the given logger.
+//
+// Related config setting(s):
+//
+//   - `LoggerConfig.ColorTreeLines`
+func (t *Tree) Print(logger *Logger) {
+	for _, line := range t.Lines() {
+		logger.writeBlock(line)
+	}
+}
+
+// addTreeValue adds `v` as child of `n`, maps, slices, arrays and structs (of any type, also behind pointers)
+// become branches with their entries (exported fields for structs) as children.
+// Values that implement fmt.Stringer or error (e.g. time.Time) are shown as they are, byte slices as strings.
+// Pointers, maps and slices that refer to a value that is already being added (cycles) are shown as "(cycle)".
+// `key` is nil for elements of slices.
+func addTreeValue(n *TreeNode, key, v any) {
+	addTreeValueWithPath(n, key, v, map[uintptr]bool{})
+}
+
+// addTreeValueWithPath adds `v` like addTreeValue, `path` contains the pointers of the values
+// (pointers, maps and slices) above `v`.
+func addTreeValueWithPath(n *TreeNode, key, v any, path map[uintptr]bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() || isTreeLeaf(rv) {
+			break
+		}
+		if rv.Kind() == reflect.Pointer {
+			if path[rv.Pointer()] {
+				v = "(cycle)"
+				rv = reflect.ValueOf(v)
+				break
+			}
+			path[rv.Pointer()] = true
+			defer delete(path, rv.Pointer())
+		}
+		rv = rv.Elem()
+	}
+	if isTreeLeaf(rv) {
+		switch {
+		case rv.Kind() == reflect.Pointer && rv.IsNil():
+			v = nil // shown like untyped nil values
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			v = string(rv.Bytes())
+		}
+		if key == nil {
+			n.Add(v)
+			return
+		}
+		n.AddKeyValue(key, v)
+		return
+	}
+	if (rv.Kind() == reflect.Map && !rv.IsNil()) || (rv.Kind() == reflect.Slice && rv.Len() > 0) {
+		if path[rv.Pointer()] {
+			addTreeValueWithPath(n, key, "(cycle)", path)
+			return
+		}
+		path[rv.Pointer()] = true
+		defer delete(path, rv.Pointer())
+	}
+
+	branch := n.AddKeyValue(key, nil)
+	switch rv.Kind() {
+	case reflect.Map:
+		if key == nil {
+			branch.Key = "{}"
+		}
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			a, b := keys[i].Interface(), keys[j].Interface()
+			if sa, ok := a.(string); ok {
+				if sb, ok := b.(string); ok {
+					return sa < sb
+				}
+			}
+			return compareValues(a, b) < 0
+		})
+		for _, k := range keys {
+			addTreeValueWithPath(branch, k.Interface(), rv.MapIndex(k).Interface(), path)
+		}
+	case reflect.Struct:
+		if key == nil {
+			branch.Key = "{}"
+		}
+		for i := 0; i < rv.NumField(); i++ {
+			if f := rv.Type().Field(i); f.IsExported() {
+				addTreeValueWithPath(branch, f.Name, rv.Field(i).Interface(), path)
+			}
+		}
+	default: // slices and arrays
+		if key == nil {
+			branch.Key = "[]"
+		}
+		for i := 0; i < rv.Len(); i++ {
+			addTreeValueWithPath(branch, nil, rv.Index(i).Interface(), path)
+		}
+	}
+}
+
+// isTreeLeaf returns true if `rv` isn't a container that becomes a branch, see addTreeValue.
+func isTreeLeaf(rv reflect.Value) bool {
+	if !rv.IsValid() {
+		return true
+	}
+	if rv.CanInterface() {
+		switch rv.Interface().(type) {
+		case fmt.Stringer, error, []byte:
+			return true
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		return false
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+	return true
+}
+
+// NewTreeFromMap creates a tree from nested maps: every key becomes a node, values that are
+// maps, slices, arrays or structs (of any type) become branches, all other values are shown as "key: value".
+// Keys are sorted alphabetically (other keys like values when sorting tables), struct fields keep their order.
+func NewTreeFromMap(root any, data map[string]any) *Tree {
+	t := NewTree(root)
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		addTreeValue(t.root, k, data[k])
+	}
+	return t
+}
+
+// NewTreeFromDir creates a tree of the directory `path` and everything below it.
+// Directories are listed before files and get a trailing slash, symlinks are not followed.
+func NewTreeFromDir(path string) (*Tree, error) {
+	t := NewTree(filepath.Clean(path) + string(filepath.Separator))
+	var walk func(n *TreeNode, dir string) error
+	walk = func(n *TreeNode, dir string) error {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].IsDir() && !entries[j].IsDir() })
+		for _, e := range entries {
+			if !e.IsDir() {
+				n.Add(e.Name())
+				continue
+			}
+			if err := walk(n.Add(e.Name()+string(filepath.Separator)), filepath.Join(dir, e.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(t.root, path); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// decodeJSONTree decodes the next JSON value from `dec` and adds it as child of `n`,
+// keys of objects keep the order of the document.
+func decodeJSONTree(dec *json.Decoder, n *TreeNode, key any) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch v := tok.(type) {
+	case json.Delim:
+		branch := n.AddKeyValue(key, nil)
+		if key == nil {
+			branch.Key = string(v) + string(v+2) // "{}" or "[]"
+		}
+		for dec.More() {
+			var childKey any
+			if v == '{' {
+				k, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				childKey = k
+			}
+			if err := decodeJSONTree(dec, branch, childKey); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token() // closing delimiter
+		return err
+	case json.Number:
+		var num any = v.String()
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			num = i
+		} else if f, err := v.Float64(); err == nil {
+			num = f
+		}
+		addTreeValue(n, key, num)
+	case string:
+		addTreeValue(n, key, strconv.Quote(v))
+	case nil:
+		addTreeValue(n, key, "null")
+	default:
+		addTreeValue(n, key, v)
+	}
+	return nil
+}
+
+// NewTreeFromJSON reads a JSON document from `r` and creates a tree from it: object keys become nodes
+// (in the order of the document), objects and arrays become branches and all other values are
+// shown as "key: value" or, within arrays, as value. Strings are quoted to distinguish them from other values.
+func NewTreeFromJSON(root any, r io.Reader) (*Tree, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	t := NewTree(root)
+	if err := decodeJSONTree(dec, t.root, nil); err != nil {
+		return nil, err
+	}
+	// the document itself is represented by the root
+	if doc := t.root.children[0]; len(doc.children) > 0 || doc.Value == nil {
+		t.root.children = doc.children
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON document")
+	}
+	return t, nil
+}
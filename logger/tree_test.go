@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/toxyl/glog/utils"
+)
+
+type treeServer struct {
+	Name    string
+	Ports   []int
+	Parent  *treeServer
+	Started time.Time
+	secret  string
+}
+
+type treeVersion struct{ Major, Minor int }
+
+func (v treeVersion) String() string { return fmt.Sprintf("%d.%d", v.Major, v.Minor) }
+
+func TestNewTreeFromMapReflection(t *testing.T) {
+	web := &treeServer{Name: "web", Ports: []int{80, 443}, Started: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), secret: "x"}
+	web.Parent = web
+	selfMap := map[string]any{"a": 1}
+	selfMap["self"] = selfMap
+	selfSlice := []any{1, nil}
+	selfSlice[1] = selfSlice
+	shared := map[string]any{"k": 1}
+
+	tests := []struct {
+		name     string
+		value    any
+		expected []string
+	}{
+		{
+			name:     "typed map",
+			value:    map[string]int{"b": 2, "a": 1},
+			expected: []string{"v", "├── a: 1", "└── b: 2"},
+		},
+		{
+			name:     "keys that aren't strings are sorted like table values",
+			value:    map[int]string{10: "ten", 2: "two"},
+			expected: []string{"v", "├── 2: two", "└── 10: ten"},
+		},
+		{
+			name:     "typed slice",
+			value:    []string{"x", "y"},
+			expected: []string{"v", "├── x", "└── y"},
+		},
+		{
+			name:     "array",
+			value:    [2]bool{true, false},
+			expected: []string{"v", "├── true", "└── false"},
+		},
+		{
+			name:     "slice of maps",
+			value:    []map[string]any{{"k": 1}},
+			expected: []string{"v", "└── {}", "    └── k: 1"},
+		},
+		{
+			name:  "struct behind a pointer, cycles are cut",
+			value: web,
+			expected: []string{
+				"v",
+				"├── Name: web",
+				"├── Ports",
+				"│   ├── 80",
+				"│   └── 443",
+				"├── Parent: (cycle)",
+				"└── Started: 2024-05-06 07:08:09",
+			},
+		},
+		{
+			name:     "map that contains itself",
+			value:    selfMap,
+			expected: []string{"v", "├── a: 1", "└── self: (cycle)"},
+		},
+		{
+			name:     "slice that contains itself",
+			value:    selfSlice,
+			expected: []string{"v", "├── 1", "└── (cycle)"},
+		},
+		{
+			name:     "repeated values aren't cycles",
+			value:    []any{shared, shared},
+			expected: []string{"v", "├── {}", "│   └── k: 1", "└── {}", "    └── k: 1"},
+		},
+		{
+			name:     "nil pointer",
+			value:    (*treeServer)(nil),
+			expected: []string{"v"},
+		},
+		{
+			name:     "byte slices are strings",
+			value:    []byte("hi"),
+			expected: []string{"v: hi"},
+		},
+		{
+			name:     "stringers are leaves",
+			value:    treeVersion{1, 2},
+			expected: []string{"v: 1.2"},
+		},
+	}
+
+	for _, tt := range tests {
+		lines := NewTreeFromMap("root", map[string]any{"v": tt.value}).Lines()
+		got := []string{}
+		for _, line := range lines[1:] { // without the root
+			got = append(got, string([]rune(utils.StripANSI(line))[4:])) // without the connector of "v"
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
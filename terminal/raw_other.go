@@ -0,0 +1,26 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package terminal
+
+import "errors"
+
+// State is the state of a terminal before it was put into raw mode, see MakeRaw.
+type State struct{}
+
+// IsTerminal returns true if the file descriptor `fd` refers to a terminal.
+// It's not supported on this platform and always returns false.
+func IsTerminal(fd uintptr) bool {
+	return false
+}
+
+// MakeRaw puts the terminal referred to by `fd` into raw mode.
+// It's not supported on this platform and always returns an error.
+func MakeRaw(fd uintptr) (*State, error) {
+	return nil, errors.New("raw mode is not supported on this platform")
+}
+
+// Restore returns the terminal referred to by `fd` to the given state.
+// It's not supported on this platform and always returns an error.
+func Restore(fd uintptr, state *State) error {
+	return errors.New("raw mode is not supported on this platform")
+}
@@ -0,0 +1,64 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package terminal
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// State is the state of a terminal before it was put into raw mode, see MakeRaw.
+type State struct {
+	termios syscall.Termios
+}
+
+func getTermios(fd uintptr) (*syscall.Termios, error) {
+	t := &syscall.Termios{}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(ioctlGetTermios), uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return nil, errno
+	}
+	return t, nil
+}
+
+func setTermios(fd uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(ioctlSetTermios), uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// IsTerminal returns true if the file descriptor `fd` refers to a terminal.
+func IsTerminal(fd uintptr) bool {
+	_, err := getTermios(fd)
+	return err == nil
+}
+
+// MakeRaw puts the terminal referred to by `fd` into raw mode: input is available byte by byte,
+// without echo and without special handling of control characters (e.g. Ctrl+C).
+// Output processing stays enabled, so "\n" still starts a new line.
+// Use Restore with the returned state to return to the previous mode.
+func MakeRaw(fd uintptr) (*State, error) {
+	t, err := getTermios(fd)
+	if err != nil {
+		return nil, err
+	}
+	state := &State{termios: *t}
+
+	t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	t.Cflag &^= syscall.CSIZE | syscall.PARENB
+	t.Cflag |= syscall.CS8
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+	if err := setTermios(fd, t); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Restore returns the terminal referred to by `fd` to the given state.
+func Restore(fd uintptr, state *State) error {
+	return setTermios(fd, &state.termios)
+}
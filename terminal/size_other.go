@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package terminal
+
+import "errors"
+
+// Size returns the number of columns and rows of the terminal referred to by the file descriptor `fd`.
+// It's not supported on this platform and always returns an error.
+func Size(fd uintptr) (cols, rows int, err error) {
+	return 0, 0, errors.New("terminal size detection is not supported on this platform")
+}
@@ -0,0 +1,35 @@
+// Package terminal provides information about the terminal the program is running in.
+package terminal
+
+import (
+	"os"
+	"strconv"
+)
+
+// Width returns the number of columns of the terminal attached to stdout.
+// If that can't be determined (e.g. because stdout is redirected),
+// the `COLUMNS` environment variable is used. If that isn't set either, 0 is returned.
+func Width() int {
+	if cols, _, err := Size(os.Stdout.Fd()); err == nil && cols > 0 {
+		return cols
+	}
+	return envInt("COLUMNS")
+}
+
+// Height returns the number of rows of the terminal attached to stdout.
+// If that can't be determined (e.g. because stdout is redirected),
+// the `LINES` environment variable is used. If that isn't set either, 0 is returned.
+func Height() int {
+	if _, rows, err := Size(os.Stdout.Fd()); err == nil && rows > 0 {
+		return rows
+	}
+	return envInt("LINES")
+}
+
+func envInt(name string) int {
+	n, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -24,6 +24,7 @@ type StackTraceFrame = logger.StackTraceFrame
 type StackTracer = logger.Tracer
 type DurationScale = utils.DurationScale
 type PathType = utils.PathType
+type HTMLOptions = ansi.HTMLOptions
 
 const (
 	INVALID_PATH            = utils.INVALID_PATH
@@ -71,6 +72,14 @@ var (
 	PlaintextString       = utils.PlaintextString
 	PlaintextStringLength = utils.PlaintextStringLength
 
+	// Display width (terminal columns)
+	StringWidth = utils.StringWidth
+	RuneWidth   = utils.RuneWidth
+
+	// Truncation and wrapping (ANSI-aware)
+	Truncate = utils.Truncate
+	WrapText = utils.Wrap
+
 	// Progress
 	ProgressBar = logger.ProgressBar
 
@@ -110,6 +119,12 @@ var (
 	ClearLineToCursor     = ansi.ClearLineToCursor().String
 	EnableLineWrap        = ansi.EnableLineWrap().String
 	DisableLineWrap       = ansi.DisableLineWrap().String
+
+	// HTML
+	NewHTMLOptions = ansi.NewHTMLOptions
+	ToHTML         = ansi.ToHTML
+	WriteHTML      = ansi.WriteHTML
+	HTMLStylesheet = ansi.HTMLStylesheet
 )
 
 // Colors
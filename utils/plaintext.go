@@ -2,20 +2,16 @@ package utils
 
 import (
 	"bytes"
-	"regexp"
 	"strings"
 	"unicode"
 	"unicode/utf8"
-)
 
-var (
-	reANSI = regexp.MustCompile(`\x1b\[(?:[0-9]{1,4}(?:;[0-9]{0,4})*)?[mABCDHfKsu]`)
+	"github.com/toxyl/glog/ansi"
 )
 
+// StripANSI removes all ANSI escape sequences (CSI, OSC, DCS, ...) from `str`.
 func StripANSI(str string) string {
-	return reANSI.ReplaceAllStringFunc(str, func(s string) string {
-		return ""
-	})
+	return ansi.Strip(str)
 }
 
 func ReplaceRunes(str string, replacement string, list []rune) string {
@@ -68,6 +64,8 @@ func PlaintextString(str string) string {
 	return str
 }
 
+// PlaintextStringLength returns the number of printable characters of `str`.
+// Emojis count as a single character, use StringWidth to get the number of terminal columns instead.
 func PlaintextStringLength(str string) int {
 	str = ReplaceRunes(str, " ", []rune{'μ', 'µ'}) // for padding calculations we actually need to count emojis as two
 	str = ReplaceEmojis(str, " ")
@@ -77,10 +75,6 @@ func PlaintextStringLength(str string) int {
 }
 
 func plaintextStringLengthForPadding(str string, padChar rune) int {
-	str = strings.ReplaceAll(str, string(padChar), " ") // replace surrounding padding characters with single space
-	str = ReplaceRunes(str, " ", []rune{'μ', 'µ'})      // for padding calculations we actually need to count emojis as two chars
-	str = ReplaceEmojis(str, "  ")                      // for padding calculations we actually need to count emojis as two chars
-	str = RemoveNonPrintable(str)
-
-	return utf8.RuneCountInString(str)
+	str = strings.ReplaceAll(str, string(padChar), " ") // padding characters always count as one column
+	return StringWidth(str)
 }
@@ -0,0 +1,82 @@
+package utils
+
+import "testing"
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{
+			input:    "hello world",
+			expected: 11,
+		},
+		{
+			input:    "\033[1;31mhello world\033[0m",
+			expected: 11,
+		},
+		{
+			input:    "1.00 µm",
+			expected: 7,
+		},
+		{
+			input:    "日本語",
+			expected: 6,
+		},
+		{
+			input:    "ｆｕｌｌ",
+			expected: 8,
+		},
+		{
+			input:    "한국어 text",
+			expected: 11,
+		},
+		{
+			input:    "e\u0301te\u0301", // combining acute accents
+			expected: 3,
+		},
+		{
+			input:    "hello 😃 world",
+			expected: 14,
+		},
+		{
+			input:    "👨‍👩‍👧", // family (ZWJ sequence)
+			expected: 2,
+		},
+		{
+			input:    "👍🏽", // skin tone modifier
+			expected: 2,
+		},
+		{
+			input:    "🇩🇪🇫🇷", // flags (regional indicator pairs)
+			expected: 4,
+		},
+		{
+			input:    "❤️", // heart with emoji presentation
+			expected: 2,
+		},
+		{
+			input:    "❤", // heart with default (text) presentation
+			expected: 1,
+		},
+		{
+			input:    "1️⃣", // keycap
+			expected: 2,
+		},
+		{
+			input:    "zero\u200Bwidth",
+			expected: 9,
+		},
+		{
+			input:    "┌─┬─┐",
+			expected: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		got := StringWidth(tt.input)
+		if got != tt.expected {
+			t.Errorf("StringWidth(%q) = %d, want %d", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package utils
+
+import "github.com/toxyl/glog/ansi"
+
+// cell is a single user-perceived character together with its style.
+type cell struct {
+	text  string
+	width int
+	style ansi.Style
+}
+
+func (c cell) isSpace() bool {
+	return c.text == " " || c.text == "\t"
+}
+
+// cells splits `str` into styled characters. Escape sequences other than SGR are dropped.
+func cells(str string) []cell {
+	res := []cell{}
+	for _, sp := range ansi.Parse(str) {
+		for t := sp.Text; len(t) > 0; {
+			size, w := nextCluster(t)
+			res = append(res, cell{text: t[:size], width: w, style: sp.Style})
+			t = t[size:]
+		}
+	}
+	return res
+}
+
+func cellsWidth(cs []cell) int {
+	w := 0
+	for _, c := range cs {
+		w += c.width
+	}
+	return w
+}
+
+// renderCells turns styled characters back into a string that re-opens
+// its styles at the start and resets them at the end.
+func renderCells(cs []cell) string {
+	spans := []ansi.Span{}
+	for _, c := range cs {
+		if n := len(spans); n > 0 && spans[n-1].Style == c.style {
+			spans[n-1].Text += c.text
+			continue
+		}
+		spans = append(spans, ansi.Span{Text: c.text, Style: c.style})
+	}
+	return ansi.Render(spans)
+}
+
+// Truncate shortens `str` to at most `width` terminal columns. If it has to be shortened,
+// `ellipsis` is appended (within `width`), using the style active at the cut.
+// ANSI styles are preserved and properly closed, other escape sequences are dropped.
+func Truncate(str string, width int, ellipsis string) string {
+	if StringWidth(str) <= width {
+		return str
+	}
+	ew := StringWidth(ellipsis)
+	if ew > width {
+		ellipsis, ew = "", 0
+	}
+
+	cs := cells(str)
+	res := []cell{}
+	w := 0
+	for _, c := range cs {
+		if w+c.width > width-ew {
+			break
+		}
+		res = append(res, c)
+		w += c.width
+	}
+	if ellipsis != "" {
+		style := ansi.NewStyle()
+		if n := len(res); n > 0 {
+			style = res[n-1].style
+		} else if len(cs) > 0 {
+			style = cs[0].style
+		}
+		res = append(res, cell{text: ellipsis, width: ew, style: style})
+	}
+	return renderCells(res)
+}
+
+// Wrap breaks `str` into lines of at most `width` terminal columns, preferably at spaces.
+// Words longer than `width` are split and existing line breaks are kept.
+// Styles active at the end of a line are reset there and re-opened on the continuation line.
+// Escape sequences other than SGR are dropped.
+//
+// If `width` is less than 1, `str` is only split at its line breaks (see SplitLines).
+func Wrap(str string, width int) []string {
+	if width < 1 {
+		return SplitLines(str)
+	}
+
+	lines := []string{}
+	for _, paragraph := range paragraphs(cells(str)) {
+		lines = append(lines, wrapParagraph(paragraph, width)...)
+	}
+	return lines
+}
+
+// SplitLines splits `str` at its line breaks. Styles active at the end of a line
+// are reset there and re-opened on the next line, so every line can be printed on its own.
+// Escape sequences other than SGR are dropped.
+func SplitLines(str string) []string {
+	lines := []string{}
+	for _, paragraph := range paragraphs(cells(str)) {
+		lines = append(lines, renderCells(paragraph))
+	}
+	return lines
+}
+
+// paragraphs splits styled characters at line breaks.
+func paragraphs(cs []cell) [][]cell {
+	res := [][]cell{}
+	paragraph := []cell{}
+	for _, c := range cs {
+		if c.text == "\n" {
+			res = append(res, paragraph)
+			paragraph = []cell{}
+			continue
+		}
+		paragraph = append(paragraph, c)
+	}
+	return append(res, paragraph)
+}
+
+func wrapParagraph(cs []cell, width int) []string {
+	lines := []string{}
+	line := []cell{}
+	lineWidth := 0
+	spaces := []cell{}
+	word := []cell{}
+	newLine := func() {
+		lines = append(lines, renderCells(line))
+		line = []cell{}
+		lineWidth = 0
+		spaces = []cell{} // spaces at the wrap point are dropped
+	}
+	flushWord := func() {
+		if len(word) == 0 {
+			return
+		}
+		ww := cellsWidth(word)
+		sw := cellsWidth(spaces)
+		if lineWidth > 0 && lineWidth+sw+ww > width {
+			newLine()
+			sw = 0
+		}
+		if lineWidth+sw+ww <= width {
+			line = append(append(line, spaces...), word...)
+			lineWidth += sw + ww
+			spaces = []cell{}
+			word = []cell{}
+			return
+		}
+		// the word doesn't fit on a line of its own, let's split it
+		line = append(line, spaces...)
+		lineWidth += sw
+		spaces = []cell{}
+		for _, c := range word {
+			if lineWidth+c.width > width && lineWidth > 0 {
+				newLine()
+			}
+			line = append(line, c)
+			lineWidth += c.width
+		}
+		word = []cell{}
+	}
+
+	for _, c := range cs {
+		if c.isSpace() {
+			flushWord()
+			spaces = append(spaces, c)
+			continue
+		}
+		word = append(word, c)
+	}
+	flushWord()
+	if lineWidth+cellsWidth(spaces) <= width {
+		line = append(line, spaces...) // keep trailing spaces if they fit
+	}
+	lines = append(lines, renderCells(line))
+	return lines
+}
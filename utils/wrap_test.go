@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input    string
+		width    int
+		ellipsis string
+		expected string
+	}{
+		{
+			input:    "hello world",
+			width:    20,
+			ellipsis: "…",
+			expected: "hello world",
+		},
+		{
+			input:    "hello world",
+			width:    8,
+			ellipsis: "…",
+			expected: "hello w…",
+		},
+		{
+			input:    "\033[38;5;45mhello world\033[0m",
+			width:    8,
+			ellipsis: "...",
+			expected: "\033[38;5;45mhello...\033[0m",
+		},
+		{
+			input:    "\033[1mbold\033[0m and plain",
+			width:    6,
+			ellipsis: "",
+			expected: "\033[1mbold\033[0m a",
+		},
+		{
+			input:    "😃😃😃",
+			width:    5,
+			ellipsis: "",
+			expected: "😃😃",
+		},
+	}
+
+	for _, tt := range tests {
+		got := Truncate(tt.input, tt.width, tt.ellipsis)
+		if got != tt.expected {
+			t.Errorf("Truncate(%q, %d, %q) = %q, want %q", tt.input, tt.width, tt.ellipsis, got, tt.expected)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		input    string
+		width    int
+		expected []string
+	}{
+		{
+			input:    "short",
+			width:    10,
+			expected: []string{"short"},
+		},
+		{
+			input:    "the quick brown fox",
+			width:    10,
+			expected: []string{"the quick", "brown fox"},
+		},
+		{
+			input:    "first\nsecond line",
+			width:    8,
+			expected: []string{"first", "second", "line"},
+		},
+		{
+			input:    "abcdefghij",
+			width:    4,
+			expected: []string{"abcd", "efgh", "ij"},
+		},
+		{
+			input:    "\033[38;5;45mblue text\033[0m here",
+			width:    6,
+			expected: []string{"\033[38;5;45mblue\033[0m", "\033[38;5;45mtext\033[0m", "here"},
+		},
+		{
+			input:    "a b",
+			width:    0,
+			expected: []string{"a b"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := Wrap(tt.input, tt.width)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.expected)
+		}
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "single line",
+			expected: []string{"single line"},
+		},
+		{
+			input:    "first\n\nthird",
+			expected: []string{"first", "", "third"},
+		},
+		{
+			input:    "\033[1mbold\nstill bold\033[0m plain",
+			expected: []string{"\033[1mbold\033[0m", "\033[1mstill bold\033[0m plain"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := SplitLines(tt.input)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("SplitLines(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}